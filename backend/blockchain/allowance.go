@@ -0,0 +1,140 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ERC20 代币绑定由 abi/ERC20.abi.json 生成
+//go:generate abigen --abi abi/ERC20.abi.json --pkg blockchain --type ERC20 --out erc20.go
+
+// ErrInsufficientBalance 出价人代币余额不足
+var ErrInsufficientBalance = errors.New("insufficient token balance")
+
+// TokenStatus 出价人在出价代币上的余额和对拍卖合约的授权额度
+type TokenStatus struct {
+	Address   string `json:"address"`
+	Symbol    string `json:"symbol"`
+	Decimals  uint8  `json:"decimals"`
+	Balance   string `json:"balance"`   // 余额（最小单位）
+	Allowance string `json:"allowance"` // 已授权给拍卖合约的额度（最小单位）
+	Required  string `json:"required"`  // 本次出价需要的额度（最小单位）
+}
+
+// BidPlan 出价交易计划
+// ERC-20 出价授权不足时只返回 approve 交易，授权交易打包后再次请求即可得到出价交易
+type BidPlan struct {
+	Token    *TokenStatus `json:"token,omitempty"`
+	Approval *UnsignedTx  `json:"approval,omitempty"`
+	Bid      *UnsignedTx  `json:"bid,omitempty"`
+}
+
+// PlanBid 构造出价流程：ETH 出价直接返回出价交易；ERC-20 出价先检查余额和授权
+func (cs *ContractService) PlanBid(ctx context.Context, from common.Address, auctionID, amount *big.Int, tokenAddress common.Address) (*BidPlan, error) {
+	if tokenAddress == (common.Address{}) {
+		bid, err := cs.BuildPlaceBidTx(ctx, from, auctionID, amount, tokenAddress)
+		if err != nil {
+			return nil, err
+		}
+		return &BidPlan{Bid: bid}, nil
+	}
+
+	status, err := cs.TokenStatus(ctx, tokenAddress, from, amount)
+	if err != nil {
+		return nil, err
+	}
+	plan := &BidPlan{Token: status}
+
+	balance, _ := new(big.Int).SetString(status.Balance, 10)
+	if balance.Cmp(amount) < 0 {
+		return plan, fmt.Errorf("%w: have %s %s, need %s", ErrInsufficientBalance, status.Balance, status.Symbol, amount)
+	}
+
+	// 合约通过 transferFrom 收取代币，授权不足时出价交易必然失败，先让用户授权
+	allowance, _ := new(big.Int).SetString(status.Allowance, 10)
+	if allowance.Cmp(amount) < 0 {
+		plan.Approval, err = cs.BuildApproveTx(ctx, from, tokenAddress, amount)
+		if err != nil {
+			return nil, err
+		}
+		return plan, nil
+	}
+
+	plan.Bid, err = cs.BuildPlaceBidTx(ctx, from, auctionID, amount, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// TokenStatus 读取代币信息、出价人余额和对拍卖合约的授权额度
+func (cs *ContractService) TokenStatus(ctx context.Context, tokenAddress, owner common.Address, required *big.Int) (*TokenStatus, error) {
+	token, err := NewERC20Caller(tokenAddress, cs.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	decimals, err := token.Decimals(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	// symbol 在部分代币中不是标准字符串，读取失败时不影响出价
+	symbol, err := token.Symbol(opts)
+	if err != nil {
+		symbol = ""
+	}
+	balance, err := token.BalanceOf(opts, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token balance: %w", err)
+	}
+	allowance, err := token.Allowance(opts, owner, cs.contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token allowance: %w", err)
+	}
+
+	return &TokenStatus{
+		Address:   tokenAddress.Hex(),
+		Symbol:    symbol,
+		Decimals:  decimals,
+		Balance:   balance.String(),
+		Allowance: allowance.String(),
+		Required:  required.String(),
+	}, nil
+}
+
+// BuildApproveTx 构造授权拍卖合约使用代币的 approve 交易，授权额度为本次出价金额
+func (cs *ContractService) BuildApproveTx(ctx context.Context, from, tokenAddress common.Address, amount *big.Int) (*UnsignedTx, error) {
+	tokenABI, err := ERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token ABI: %w", err)
+	}
+	return cs.buildCall(ctx, from, tokenAddress, tokenABI, big.NewInt(0), "approve", cs.contractAddress, amount)
+}
+
+// approvalMethod 判断调用数据是否为授权拍卖合约的 approve，用于广播接口放行代币授权交易
+func (cs *ContractService) approvalMethod(data []byte) (*abi.Method, []interface{}, bool) {
+	tokenABI, err := ERC20MetaData.GetAbi()
+	if err != nil || len(data) < 4 {
+		return nil, nil, false
+	}
+	method, err := tokenABI.MethodById(data[:4])
+	if err != nil || method.Name != "approve" {
+		return nil, nil, false
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil || len(args) != 2 {
+		return nil, nil, false
+	}
+	spender, ok := args[0].(common.Address)
+	if !ok || spender != cs.contractAddress {
+		return nil, nil, false
+	}
+	return method, args, true
+}
@@ -0,0 +1,189 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SyncProgress 同步进度
+type SyncProgress struct {
+	Mode         string    `json:"mode"`          // subscription, polling
+	CurrentBlock uint64    `json:"current_block"` // 已处理到的区块
+	TargetBlock  uint64    `json:"target_block"`  // 本轮补齐的目标区块
+	ChunkSize    uint64    `json:"chunk_size"`    // 当前查询跨度
+	ParkedLogs   int       `json:"parked_logs"`   // 被搁置的日志数
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// rangeLimitErrors RPC 服务商拒绝过大查询范围时的常见错误信息
+var rangeLimitErrors = []string{
+	"too many",
+	"query returned more than",
+	"block range",
+	"range is too large",
+	"range too large",
+	"limit exceeded",
+	"response size",
+	"exceed maximum",
+}
+
+// Progress 返回当前同步进度
+func (el *EventListener) Progress() SyncProgress {
+	el.progressMu.RLock()
+	defer el.progressMu.RUnlock()
+	return el.progress
+}
+
+// setMode 设置当前监听模式
+func (el *EventListener) setMode(mode string) {
+	el.progressMu.Lock()
+	defer el.progressMu.Unlock()
+	el.progress.Mode = mode
+	el.progress.UpdatedAt = time.Now()
+}
+
+// reportProgress 更新并输出补齐进度
+func (el *EventListener) reportProgress(fromBlock, current, target uint64) {
+	el.progressMu.Lock()
+	el.progress.CurrentBlock = current
+	el.progress.TargetBlock = target
+	el.progress.ChunkSize = el.chunkSize
+	el.progress.UpdatedAt = time.Now()
+	el.progressMu.Unlock()
+
+	total := target - fromBlock + 1
+	done := current - fromBlock + 1
+	log.Printf("Backfill progress: block %d/%d (%.1f%%), chunk size %d\n",
+		current, target, float64(done)*100/float64(total), el.chunkSize)
+}
+
+// pollLogs 轮询方式获取日志，订阅不可用时按固定间隔持续补齐到最新区块
+func (el *EventListener) pollLogs(ctx context.Context) error {
+	el.setMode("polling")
+
+	interval := time.Duration(config.AppConfig.PollInterval) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := el.pollOnce(ctx); err != nil {
+			log.Printf("Polling error: %v\n", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Println("Event listener stopped")
+			return nil
+		}
+	}
+}
+
+// pollOnce 执行一轮轮询：重组检测、补齐、实现检查、确认
+func (el *EventListener) pollOnce(ctx context.Context) error {
+	if err := el.checkReorg(ctx); err != nil {
+		return err
+	}
+	if err := el.backfill(ctx, el.resumeBlock()); err != nil {
+		return err
+	}
+	if err := el.checkImplementation(ctx); err != nil {
+		log.Printf("Implementation check failed: %v\n", err)
+	}
+	return el.confirmBlocks(ctx)
+}
+
+// backfill 分段补齐从 fromBlock 到最新区块的历史日志，查询范围被拒绝时自动缩小跨度
+func (el *EventListener) backfill(ctx context.Context, fromBlock uint64) error {
+	header, err := el.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	head := header.Number.Uint64()
+	if fromBlock > head {
+		return nil
+	}
+
+	maxChunk := config.AppConfig.LogChunkSize
+	if maxChunk == 0 {
+		maxChunk = 1
+	}
+	if el.chunkSize == 0 || el.chunkSize > maxChunk {
+		el.chunkSize = maxChunk
+	}
+
+	log.Printf("Backfilling logs from block %d to %d...\n", fromBlock, head)
+
+	for start := fromBlock; start <= head; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := start + el.chunkSize - 1
+		if end > head {
+			end = head
+		}
+
+		query := ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{el.contractAddress},
+		}
+
+		logs, err := el.client.FilterLogs(ctx, query)
+		if err != nil {
+			if isRangeLimitError(err) && el.chunkSize > 1 {
+				el.chunkSize /= 2
+				log.Printf("Log range %d-%d rejected, shrinking chunk size to %d\n", start, end, el.chunkSize)
+				continue
+			}
+			return fmt.Errorf("failed to filter logs %d-%d: %w", start, end, err)
+		}
+
+		for _, vLog := range logs {
+			if err := el.handleLog(vLog); err != nil {
+				return err
+			}
+		}
+
+		// 区间内的日志已全部处理，游标推进到区间末尾；最新区块额外记录哈希用于重组检测
+		blockHash := common.Hash{}
+		if end == head {
+			blockHash = header.Hash()
+		}
+		if err := el.markBlockDone(end, blockHash); err != nil {
+			return err
+		}
+		el.reportProgress(fromBlock, end, head)
+
+		// 查询成功后逐步恢复跨度
+		if el.chunkSize < maxChunk {
+			el.chunkSize *= 2
+			if el.chunkSize > maxChunk {
+				el.chunkSize = maxChunk
+			}
+		}
+		start = end + 1
+	}
+
+	return nil
+}
+
+// isRangeLimitError 判断是否为查询范围或结果数超限错误
+func isRangeLimitError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range rangeLimitErrors {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
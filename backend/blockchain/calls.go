@@ -0,0 +1,348 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+// 索引模式
+const (
+	IndexModeEvents = "events" // 订阅合约事件
+	IndexModeCalls  = "calls"  // 解码发往合约的交易调用
+	IndexModeState  = "state"  // 仅定期读取合约状态
+)
+
+// callRecord 一笔发往合约且执行成功的交易调用
+type callRecord struct {
+	block  *types.Block
+	tx     *types.Transaction
+	index  uint // 交易在区块中的序号，在游标和账本中代替日志序号
+	from   common.Address
+	method *abi.Method
+	args   map[string]interface{}
+}
+
+// pollCalls 合约不发事件时的索引方式：逐块解码发往合约的交易，并定期读取合约状态校正
+func (el *EventListener) pollCalls(ctx context.Context) error {
+	mode := config.AppConfig.IndexMode
+	el.setMode(mode)
+	log.Printf("Event listener started in %s mode\n", mode)
+
+	interval := time.Duration(config.AppConfig.PollInterval) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastStateSync time.Time
+	stateInterval := time.Duration(config.AppConfig.StateSyncInterval) * time.Second
+
+	for {
+		if mode == IndexModeCalls {
+			if err := el.pollCallsOnce(ctx); err != nil {
+				log.Printf("Call indexing error: %v\n", err)
+			} else if err := el.checkImplementation(ctx); err != nil {
+				log.Printf("Implementation check failed: %v\n", err)
+			}
+		}
+
+		if stateInterval > 0 && time.Since(lastStateSync) >= stateInterval {
+			if err := el.syncState(ctx); err != nil {
+				log.Printf("State sync error: %v\n", err)
+			} else {
+				lastStateSync = time.Now()
+			}
+		}
+
+		if err := el.confirmBlocks(ctx); err != nil {
+			log.Printf("Failed to confirm blocks: %v\n", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.Println("Event listener stopped")
+			return nil
+		}
+	}
+}
+
+// pollCallsOnce 执行一轮调用解码：重组检测后从游标处扫描到最新区块
+func (el *EventListener) pollCallsOnce(ctx context.Context) error {
+	if err := el.checkReorg(ctx); err != nil {
+		return err
+	}
+	return el.scanCalls(ctx, el.resumeBlock())
+}
+
+// scanCalls 逐块扫描 fromBlock 到最新区块中发往合约的交易
+func (el *EventListener) scanCalls(ctx context.Context, fromBlock uint64) error {
+	head, err := el.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if fromBlock > head {
+		return nil
+	}
+
+	log.Printf("Scanning transactions from block %d to %d...\n", fromBlock, head)
+
+	for number := fromBlock; number <= head; number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		block, err := el.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", number, err)
+		}
+
+		for i, tx := range block.Transactions() {
+			if tx.To() == nil || *tx.To() != el.contractAddress {
+				continue
+			}
+			if err := el.handleCall(ctx, block, tx, uint(i)); err != nil {
+				return err
+			}
+		}
+
+		if err := el.markBlockDone(number, block.Hash()); err != nil {
+			return err
+		}
+		el.reportProgress(fromBlock, number, head)
+	}
+
+	return nil
+}
+
+// handleCall 解码并应用单笔交易调用，失败的交易和无关的方法直接跳过
+// 参数无法解码或无法对应到本地记录的调用与日志一样写入死信表，状态校正仍会补齐拍卖数据
+func (el *EventListener) handleCall(ctx context.Context, block *types.Block, tx *types.Transaction, index uint) error {
+	if el.isProcessed(types.Log{BlockNumber: block.NumberU64(), Index: index}) {
+		return nil
+	}
+
+	call, err := el.decodeCall(ctx, block, tx, index)
+	if err != nil {
+		if call != nil && isPermanentError(err) {
+			return el.parkCall(call, err)
+		}
+		return err
+	}
+	if call == nil {
+		return nil
+	}
+
+	for attempt := 1; attempt <= maxApplyAttempts; attempt++ {
+		if err = el.applyCall(ctx, call); err == nil {
+			el.advanceCursor(block.NumberU64(), int(index))
+			return nil
+		}
+		if isPermanentError(err) {
+			return el.parkCall(call, err)
+		}
+
+		log.Printf("Failed to process call %s (attempt %d/%d): %v\n",
+			tx.Hash().Hex(), attempt, maxApplyAttempts, err)
+		if attempt < maxApplyAttempts {
+			time.Sleep(applyRetryBackoff << (attempt - 1))
+		}
+	}
+
+	return fmt.Errorf("failed to process call %s: %w", tx.Hash().Hex(), err)
+}
+
+// decodeCall 按交易所在区块生效的实现版本解析方法和参数
+// 只返回执行成功的 createAuction、placeBid、endAuction 和 upgradeToAndCall 调用
+// 参数解码失败时返回不含参数的调用和 errDecode，供写入死信表
+func (el *EventListener) decodeCall(ctx context.Context, block *types.Block, tx *types.Transaction, index uint) (*callRecord, error) {
+	data := tx.Data()
+	if len(data) < 4 {
+		return nil, nil
+	}
+	method := el.upgradeMethod(data[:4])
+	if method == nil {
+		var err error
+		if method, err = el.abiAt(block.NumberU64()).MethodById(data[:4]); err != nil {
+			return nil, nil
+		}
+	}
+	switch method.Name {
+	case "createAuction", "placeBid", "endAuction", "upgradeToAndCall":
+	default:
+		return nil, nil
+	}
+
+	receipt, err := el.client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, nil
+	}
+
+	args := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+		call := &callRecord{block: block, tx: tx, index: index, method: method}
+		return call, fmt.Errorf("%w: failed to unpack %s: %v", errDecode, method.Name, err)
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender of %s: %w", tx.Hash().Hex(), err)
+	}
+
+	return &callRecord{
+		block:  block,
+		tx:     tx,
+		index:  index,
+		from:   from,
+		method: method,
+		args:   args,
+	}, nil
+}
+
+// applyCall 在单个事务中应用调用：账本、业务数据和同步游标要么全部写入，要么全部回滚
+func (el *EventListener) applyCall(ctx context.Context, call *callRecord) error {
+	blockNumber := call.block.NumberU64()
+	baseID, err := el.callBaseID(ctx, call)
+	if err != nil {
+		return err
+	}
+
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := el.applyCallOnce(tx, call, baseID); err != nil {
+			return err
+		}
+		if err := el.recordBlock(tx, blockNumber, call.block.Hash()); err != nil {
+			return err
+		}
+		return el.saveCursor(tx, blockNumber, int(call.index))
+	})
+}
+
+// callBaseID 合约按顺序分配拍卖ID，createAuction 需要先取得本区块之前的 nextAuctionId
+func (el *EventListener) callBaseID(ctx context.Context, call *callRecord) (uint64, error) {
+	if call.method.Name != "createAuction" {
+		return 0, nil
+	}
+	return el.nextAuctionIDBefore(ctx, call.block.NumberU64())
+}
+
+// applyCallOnce 通过事件账本保证同一调用只被应用一次
+func (el *EventListener) applyCallOnce(tx *gorm.DB, call *callRecord, baseID uint64) error {
+	fresh, err := el.recordLedger(tx, call.tx.Hash(), call.index, call.block.NumberU64(), call.block.Hash(), call.method.Name)
+	if err != nil || !fresh {
+		return err
+	}
+	return el.applyCallData(tx, call, baseID)
+}
+
+// applyCallData 将调用参数转换为与事件模式相同的拍卖和出价记录
+func (el *EventListener) applyCallData(tx *gorm.DB, call *callRecord, baseID uint64) error {
+	blockNumber := call.block.NumberU64()
+	blockTime := call.block.Time()
+
+	switch call.method.Name {
+	case "createAuction":
+		// 同一区块内排在前面的 createAuction 交易占用了紧随其后的ID
+		// 按账本计数而非拍卖记录，管理接口创建的拍卖可能先于监听器写入
+		var createdInBlock int64
+		if err := tx.Model(&models.Event{}).
+			Where("chain_id = ? AND contract_address = ? AND block_number = ? AND event_name = ? AND log_index < ?",
+				el.chainID, el.contractKey(), blockNumber, "createAuction", call.index).
+			Count(&createdInBlock).Error; err != nil {
+			return fmt.Errorf("failed to count auctions: %w", err)
+		}
+
+		auction := models.Auction{
+			ChainID:         el.chainID,
+			ContractAddress: el.contractKey(),
+			AuctionID:       uint(baseID + uint64(createdInBlock)),
+			Seller:          strings.ToLower(call.from.Hex()),
+			NFTContract:     strings.ToLower(call.args["_nftAddress"].(common.Address).Hex()),
+			TokenID:         call.args["_tokenId"].(*big.Int).String(),
+			StartPrice:      call.args["_startPrice"].(*big.Int).String(),
+			Duration:        call.args["_duration"].(*big.Int).Uint64(),
+			StartTime:       blockTime,
+			Ended:           false,
+			CreatedBlock:    blockNumber,
+		}
+		return saveAuction(tx, &auction)
+
+	case "placeBid":
+		tokenAddress := call.args["_tokenAddress"].(common.Address)
+		amount := call.args["amount"].(*big.Int)
+		// ETH 出价时合约以 msg.value 为准
+		if tokenAddress == (common.Address{}) {
+			amount = call.tx.Value()
+		}
+
+		bid := models.Bid{
+			ChainID:         el.chainID,
+			ContractAddress: el.contractKey(),
+			AuctionID:       uint(call.args["_auctionID"].(*big.Int).Uint64()),
+			Bidder:          strings.ToLower(call.from.Hex()),
+			Amount:          amount.String(),
+			TokenAddress:    strings.ToLower(tokenAddress.Hex()),
+			TxHash:          call.tx.Hash().Hex(),
+			LogIndex:        call.index,
+			BlockNumber:     blockNumber,
+			Timestamp:       blockTime,
+		}
+		return saveBid(tx, &bid)
+
+	case "endAuction":
+		var auction models.Auction
+		if err := tx.Scopes(el.scope()).Where("auction_id = ?", call.args["_auctionID"].(*big.Int).Uint64()).
+			First(&auction).Error; err != nil {
+			return fmt.Errorf("failed to find auction: %w", err)
+		}
+		// 合约把 NFT 转给当前最高出价者，本地记录的最高出价即成交结果
+		return closeAuction(tx, &auction, blockTime, blockNumber)
+
+	case "upgradeToAndCall":
+		// 与 Upgraded 事件一致，新实现从下一个区块开始用于解码
+		return el.recordImplementation(tx, call.args["newImplementation"].(common.Address),
+			blockNumber+1, call.tx.Hash().Hex())
+	}
+	return nil
+}
+
+// nextAuctionIDBefore 获取指定区块之前合约的 nextAuctionId
+// 节点不保留历史状态时，退回到本地在该区块之前记录的拍卖数量
+func (el *EventListener) nextAuctionIDBefore(ctx context.Context, blockNumber uint64) (uint64, error) {
+	if blockNumber > 0 {
+		caller, err := NewNftAuctionCaller(el.contractAddress, el.client)
+		if err != nil {
+			return 0, fmt.Errorf("failed to bind contract: %w", err)
+		}
+		next, err := caller.NextAuctionId(&bind.CallOpts{
+			Context:     ctx,
+			BlockNumber: new(big.Int).SetUint64(blockNumber - 1),
+		})
+		if err == nil {
+			return next.Uint64(), nil
+		}
+		log.Printf("Historical state unavailable at block %d, using local auction count: %v\n", blockNumber-1, err)
+	}
+
+	var count int64
+	if err := database.GetDB().Model(&models.Auction{}).
+		Scopes(el.scope()).Where("created_block < ?", blockNumber).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count auctions: %w", err)
+	}
+	return uint64(count), nil
+}
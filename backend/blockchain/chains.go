@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"auction-backend/database"
+	"auction-backend/models"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownChain 链未配置 RPC 地址
+var ErrUnknownChain = errors.New("no RPC URL configured for chain")
+
+// defaultChainID ETH_RPC_URL 所在链的ID，由 InitRPCPools 读取节点后设置
+var defaultChainID uint64
+
+// DefaultChainID 默认链（ETH_RPC_URL 所在的链）的ID，API 未指定链时使用
+func DefaultChainID() uint64 {
+	return defaultChainID
+}
+
+// IsKnownChain 链是否配置了 RPC 节点池
+func IsKnownChain(chainID uint64) bool {
+	_, err := GetRPCPool(chainID)
+	return err == nil
+}
+
+// Chains 已配置 RPC 节点池的链ID，默认链排在最前
+func Chains() []uint64 {
+	chains := []uint64{defaultChainID}
+	for chainID := range RPCPools() {
+		if chainID != defaultChainID {
+			chains = append(chains, chainID)
+		}
+	}
+	sort.Slice(chains[1:], func(i, j int) bool { return chains[i+1] < chains[j+1] })
+	return chains
+}
+
+// ChainDefaultContract 链上的默认拍卖合约（小写地址）：默认链为 CONTRACT_ADDRESS，其他链为最早注册的合约
+// 链上没有注册合约时返回 gorm.ErrRecordNotFound
+func ChainDefaultContract(chainID uint64) (string, error) {
+	if chainID == defaultChainID {
+		return DefaultContractKey(), nil
+	}
+
+	var contract models.Contract
+	err := database.GetDB().Where("chain_id = ?", chainID).Order("id ASC").First(&contract).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find contract: %w", err)
+	}
+	return strings.ToLower(contract.Address), nil
+}
+
+// chainContractScope 按链和拍卖合约过滤，不同链上的合约地址和拍卖ID都可能重复
+func chainContractScope(chainID uint64, contract string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("chain_id = ? AND contract_address = ?", chainID, contract)
+	}
+}
@@ -0,0 +1,45 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// confirmBlocks 将达到确认深度的出价和拍卖标记为已确认
+func (el *EventListener) confirmBlocks(ctx context.Context) error {
+	head, err := el.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	depth := config.AppConfig.Confirmations
+	if head < depth {
+		return nil
+	}
+	safeBlock := head - depth
+
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Bid{}).
+			Scopes(el.scope()).Where("confirmed = ? AND block_number <= ?", false, safeBlock).
+			Update("confirmed", true).Error; err != nil {
+			return fmt.Errorf("failed to confirm bids: %w", err)
+		}
+
+		// 拍卖的创建、结束以及所有出价都已确认时，拍卖才算最终确认
+		pendingBids := tx.Model(&models.Bid{}).Select("auction_id").
+			Where("chain_id = ? AND contract_address = ? AND confirmed = ?", el.chainID, el.contractKey(), false)
+		if err := tx.Model(&models.Auction{}).
+			Scopes(el.scope()).Where("confirmed = ? AND created_block <= ?", false, safeBlock).
+			Where("ended_block IS NULL OR ended_block <= ?", safeBlock).
+			Where("auction_id NOT IN (?)", pendingBids).
+			Update("confirmed", true).Error; err != nil {
+			return fmt.Errorf("failed to confirm auctions: %w", err)
+		}
+		return nil
+	})
+}
@@ -3,7 +3,6 @@ package blockchain
 import (
 	"auction-backend/config"
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"strings"
@@ -11,244 +10,95 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"gorm.io/gorm"
 )
 
 // ContractService 合约服务
 type ContractService struct {
-	client          *ethclient.Client
+	client          *RPCPool // 所在链的节点池
+	chainID         uint64
 	contractAddress common.Address
 	contractABI     abi.ABI
+	auction         *NftAuction // 合约类型化绑定
+	signer          Signer      // 服务端签名账户，未配置时为 nil
 }
 
-// NewContractService 创建合约服务实例
+// NewContractService 创建默认链上默认合约（CONTRACT_ADDRESS）的服务实例
 func NewContractService() (*ContractService, error) {
-	client, err := ethclient.Dial(config.AppConfig.ETHRPCURL)
+	pool, err := GetRPCPool(DefaultChainID())
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to ethereum client: %w", err)
+		return nil, err
 	}
-
-	contractABI, err := abi.JSON(strings.NewReader(NftAuctionABI))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
-	}
-
-	return &ContractService{
-		client:          client,
-		contractAddress: common.HexToAddress(config.AppConfig.ContractAddress),
-		contractABI:     contractABI,
-	}, nil
+	return NewContractServiceAt(pool, common.HexToAddress(config.AppConfig.ContractAddress))
 }
 
-// PlaceBidRequest 出价请求
-type PlaceBidRequest struct {
-	AuctionID    *big.Int       // 拍卖ID
-	Amount       *big.Int       // 出价金额
-	TokenAddress common.Address // 代币地址，0x0 表示 ETH
-	PrivateKey   string         // 用户私钥
-}
-
-// PlaceBid 参与出价
-func (cs *ContractService) PlaceBid(ctx context.Context, req PlaceBidRequest) (*types.Transaction, error) {
-	// 解析私钥
-	privateKey, err := crypto.HexToECDSA(req.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
-	}
-
-	// 获取公钥地址
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("error casting public key to ECDSA")
-	}
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-
-	// 获取 nonce
-	nonce, err := cs.client.PendingNonceAt(ctx, fromAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
-	}
-
-	// 获取 gas price
-	gasPrice, err := cs.client.SuggestGasPrice(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
-	}
-
-	// 获取 chain ID
-	chainID, err := cs.client.NetworkID(ctx)
+// NewContractServiceAt 创建拍卖合约的服务实例，合约所在的链由节点池决定
+func NewContractServiceAt(pool *RPCPool, contractAddress common.Address) (*ContractService, error) {
+	contractABI, err := NftAuctionMetaData.GetAbi()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID: %w", err)
-	}
-
-	// 创建交易选项
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create transactor: %w", err)
-	}
-
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.GasPrice = gasPrice
-	auth.GasLimit = uint64(300000) // 设置 gas limit
-
-	// 如果是 ETH 出价，设置 value
-	if req.TokenAddress == (common.Address{}) {
-		auth.Value = req.Amount
-	} else {
-		auth.Value = big.NewInt(0)
-	}
-
-	// 编码函数调用数据
-	data, err := cs.contractABI.Pack("placeBid", req.AuctionID, req.Amount, req.TokenAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to pack transaction data: %w", err)
-	}
-
-	// 创建交易
-	tx := types.NewTransaction(
-		nonce,
-		cs.contractAddress,
-		auth.Value,
-		auth.GasLimit,
-		gasPrice,
-		data,
-	)
-
-	// 签名交易
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
 	}
 
-	// 发送交易
-	err = cs.client.SendTransaction(ctx, signedTx)
+	auction, err := NewNftAuction(contractAddress, pool)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+		return nil, fmt.Errorf("failed to bind contract: %w", err)
 	}
 
-	return signedTx, nil
+	return &ContractService{
+		client:          pool,
+		chainID:         pool.chainID,
+		contractAddress: contractAddress,
+		contractABI:     *contractABI,
+		auction:         auction,
+		signer:          operatorSigner,
+	}, nil
 }
 
-// EndAuctionRequest 结束拍卖请求
-type EndAuctionRequest struct {
-	AuctionID  *big.Int // 拍卖ID
-	PrivateKey string   // 调用者私钥
+// contractKey 数据库中使用的合约地址（小写）
+func (cs *ContractService) contractKey() string {
+	return strings.ToLower(cs.contractAddress.Hex())
 }
 
-// EndAuction 结束拍卖
-func (cs *ContractService) EndAuction(ctx context.Context, req EndAuctionRequest) (*types.Transaction, error) {
-	// 解析私钥
-	privateKey, err := crypto.HexToECDSA(req.PrivateKey)
-	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
-	}
-
-	// 获取公钥地址
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("error casting public key to ECDSA")
-	}
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-
-	// 获取 nonce
-	nonce, err := cs.client.PendingNonceAt(ctx, fromAddress)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
-	}
-
-	// 获取 gas price
-	gasPrice, err := cs.client.SuggestGasPrice(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %w", err)
-	}
-
-	// 获取 chain ID
-	chainID, err := cs.client.NetworkID(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID: %w", err)
-	}
-
-	// 编码函数调用数据
-	data, err := cs.contractABI.Pack("endAuction", req.AuctionID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to pack transaction data: %w", err)
-	}
-
-	// 创建交易
-	tx := types.NewTransaction(
-		nonce,
-		cs.contractAddress,
-		big.NewInt(0),
-		uint64(300000), // gas limit
-		gasPrice,
-		data,
-	)
-
-	// 签名交易
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	// 发送交易
-	err = cs.client.SendTransaction(ctx, signedTx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
-	}
-
-	return signedTx, nil
+// scope 按服务所在的链和合约过滤拍卖、出价等业务数据
+func (cs *ContractService) scope() func(*gorm.DB) *gorm.DB {
+	return chainContractScope(cs.chainID, cs.contractKey())
 }
 
 // GetAuctionInfo 获取拍卖信息（从合约读取）
 func (cs *ContractService) GetAuctionInfo(ctx context.Context, auctionID *big.Int) (map[string]interface{}, error) {
-	// 编码函数调用
-	data, err := cs.contractABI.Pack("auctions", auctionID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to pack call data: %w", err)
-	}
-
-	// 调用合约
-	result, err := cs.client.CallContract(ctx, map[string]interface{}{
-		"to":   cs.contractAddress,
-		"data": data,
-	}, nil)
+	out, err := cs.auction.Auctions(&bind.CallOpts{Context: ctx}, auctionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
-	// 解析结果
-	var out struct {
-		Seller        common.Address
-		Duration      *big.Int
-		StartPrice    *big.Int
-		StartTime     *big.Int
-		Ended         bool
-		HighestBidder common.Address
-		HighestBid    *big.Int
-		NftContract   common.Address
-		TokenId       *big.Int
-		TokenAddress  common.Address
-	}
-
-	err = cs.contractABI.UnpackIntoInterface(&out, "auctions", result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unpack result: %w", err)
-	}
-
 	return map[string]interface{}{
 		"seller":         out.Seller.Hex(),
 		"duration":       out.Duration.String(),
 		"start_price":    out.StartPrice.String(),
 		"start_time":     out.StartTime.String(),
-		"ended":          out.Ended,
+		"ended":          out.Isend,
 		"highest_bidder": out.HighestBidder.Hex(),
-		"highest_bid":    out.HighestBid.String(),
+		"highest_bid":    out.HightestPrice.String(),
 		"nft_contract":   out.NftContract.Hex(),
 		"token_id":       out.TokenId.String(),
 		"token_address":  out.TokenAddress.Hex(),
 	}, nil
 }
+
+// NextAuctionID 获取合约中下一个拍卖ID（即已创建的拍卖数量）
+func (cs *ContractService) NextAuctionID(ctx context.Context) (*big.Int, error) {
+	next, err := cs.auction.NextAuctionId(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call contract: %w", err)
+	}
+	return next, nil
+}
+
+// Admin 获取合约管理员地址
+func (cs *ContractService) Admin(ctx context.Context) (common.Address, error) {
+	admin, err := cs.auction.Admin(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to call contract: %w", err)
+	}
+	return admin, nil
+}
@@ -0,0 +1,212 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+// ERC721 NFT 绑定由 abi/ERC721.abi.json 生成
+//go:generate abigen --abi abi/ERC721.abi.json --pkg blockchain --type ERC721 --out erc721.go
+
+var (
+	// ErrNotAdmin 服务端签名账户不是合约管理员，无法创建拍卖
+	ErrNotAdmin = errors.New("operator signer is not the contract admin")
+	// ErrNFTNotReady NFT 不属于签名账户或未授权给拍卖合约
+	ErrNFTNotReady = errors.New("nft is not ready for auction")
+)
+
+// CreateAuctionParams 创建拍卖参数
+type CreateAuctionParams struct {
+	Duration    *big.Int       // 拍卖时长（秒）
+	StartPrice  *big.Int       // 起拍价（wei）
+	NFTContract common.Address // NFT 合约地址
+	TokenID     *big.Int       // NFT Token ID
+	Category    string         // 分类，仅保存在本地
+}
+
+// CreateAuction 以服务端签名账户调用 createAuction，等待交易打包后立即写入拍卖记录
+// ctx 超时但交易已广播时返回交易和错误，拍卖仍会由监听器在交易打包后索引
+func (cs *ContractService) CreateAuction(ctx context.Context, params CreateAuctionParams) (*models.Auction, *types.Transaction, error) {
+	if cs.signer == nil {
+		return nil, nil, ErrNoSigner
+	}
+	operator := cs.signer.Address()
+
+	// createAuction 只允许管理员调用，并由合约从调用者转走 NFT
+	admin, err := cs.Admin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if admin != operator {
+		return nil, nil, fmt.Errorf("%w: admin is %s, signer is %s", ErrNotAdmin, admin.Hex(), operator.Hex())
+	}
+	if err := cs.checkNFT(ctx, operator, params.NFTContract, params.TokenID); err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := cs.Transact(ctx, big.NewInt(0), "createAuction",
+		params.Duration, params.StartPrice, params.NFTContract, params.TokenID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := bind.WaitMined(ctx, cs.client, tx)
+	if err != nil {
+		return nil, tx, fmt.Errorf("failed to wait for transaction: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, tx, fmt.Errorf("createAuction transaction %s failed", tx.Hash().Hex())
+	}
+
+	auction, err := cs.indexCreatedAuction(ctx, receipt, params)
+	if err != nil {
+		return nil, tx, err
+	}
+	return auction, tx, nil
+}
+
+// checkNFT 校验 NFT 属于签名账户且已授权拍卖合约转移
+func (cs *ContractService) checkNFT(ctx context.Context, owner, nftContract common.Address, tokenID *big.Int) error {
+	nft, err := NewERC721Caller(nftContract, cs.client)
+	if err != nil {
+		return fmt.Errorf("failed to bind nft contract: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	holder, err := nft.OwnerOf(opts, tokenID)
+	if err != nil {
+		return fmt.Errorf("%w: failed to get owner of token %s: %v", ErrNFTNotReady, tokenID, err)
+	}
+	if holder != owner {
+		return fmt.Errorf("%w: token %s is owned by %s, not %s", ErrNFTNotReady, tokenID, holder.Hex(), owner.Hex())
+	}
+
+	approved, err := nft.GetApproved(opts, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get approval of token %s: %w", tokenID, err)
+	}
+	if approved == cs.contractAddress {
+		return nil
+	}
+	approvedForAll, err := nft.IsApprovedForAll(opts, owner, cs.contractAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get operator approval: %w", err)
+	}
+	if !approvedForAll {
+		return fmt.Errorf("%w: token %s is not approved for the auction contract", ErrNFTNotReady, tokenID)
+	}
+	return nil
+}
+
+// indexCreatedAuction 找到交易创建的拍卖并写入本地，包括只存在于本地的分类
+func (cs *ContractService) indexCreatedAuction(ctx context.Context, receipt *types.Receipt, params CreateAuctionParams) (*models.Auction, error) {
+	auctionID, onchain, err := cs.findCreatedAuction(ctx, receipt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	auction := models.Auction{
+		ChainID:         cs.chainID,
+		ContractAddress: cs.contractKey(),
+		AuctionID:       uint(auctionID),
+		Seller:          strings.ToLower(onchain.Seller.Hex()),
+		NFTContract:     strings.ToLower(onchain.NftContract.Hex()),
+		TokenID:         onchain.TokenId.String(),
+		StartPrice:      onchain.StartPrice.String(),
+		Duration:        onchain.Duration.Uint64(),
+		StartTime:       onchain.StartTime.Uint64(),
+		Ended:           false,
+		Category:        params.Category,
+		CreatedBlock:    receipt.BlockNumber.Uint64(),
+	}
+
+	err = database.GetDB().Transaction(func(tx *gorm.DB) error {
+		// 调用解码模式下先登记账本，监听器扫描到该交易时不会再按区块内顺序重复分配拍卖ID
+		if config.AppConfig.IndexMode == IndexModeCalls {
+			if _, err := insertLedger(tx, &models.Event{
+				ChainID:         cs.chainID,
+				ContractAddress: cs.contractKey(),
+				TxHash:          receipt.TxHash.Hex(),
+				LogIndex:        receipt.TransactionIndex,
+				BlockNumber:     receipt.BlockNumber.Uint64(),
+				BlockHash:       receipt.BlockHash.Hex(),
+				EventName:       "createAuction",
+			}); err != nil {
+				return err
+			}
+		}
+		if err := saveAuction(tx, &auction); err != nil {
+			return err
+		}
+		// 监听器可能已先写入该拍卖，分类不在链上，需要单独更新
+		if err := tx.Model(&models.Auction{}).
+			Scopes(cs.scope()).Where("auction_id = ?", auction.AuctionID).
+			Update("category", params.Category).Error; err != nil {
+			return fmt.Errorf("failed to save auction category: %w", err)
+		}
+		if err := tx.Model(&models.Transaction{}).Where("tx_hash = ?", receipt.TxHash.Hex()).
+			Update("auction_id", auction.AuctionID).Error; err != nil {
+			return fmt.Errorf("failed to link transaction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &auction, nil
+}
+
+// findCreatedAuction 在交易所在区块新分配的拍卖ID中找到本次创建的拍卖
+// 节点不保留历史状态时，退回到最新状态中从后往前查找同一 NFT 且在该区块开始的拍卖
+func (cs *ContractService) findCreatedAuction(ctx context.Context, receipt *types.Receipt, params CreateAuctionParams) (uint64, auctionState, error) {
+	header, err := cs.client.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return 0, auctionState{}, fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber}
+	next, err := cs.auction.NextAuctionId(opts)
+	if err != nil {
+		log.Printf("Historical state unavailable at block %d, using latest state: %v\n", receipt.BlockNumber, err)
+		opts.BlockNumber = nil
+		if next, err = cs.auction.NextAuctionId(opts); err != nil {
+			return 0, auctionState{}, fmt.Errorf("failed to call contract: %w", err)
+		}
+	}
+
+	var first uint64
+	if opts.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		prev, err := cs.auction.NextAuctionId(&bind.CallOpts{
+			Context:     ctx,
+			BlockNumber: new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)),
+		})
+		if err == nil {
+			first = prev.Uint64()
+		}
+	}
+
+	for id := next.Uint64(); id > first; id-- {
+		onchain, err := cs.auction.Auctions(opts, new(big.Int).SetUint64(id-1))
+		if err != nil {
+			return 0, auctionState{}, fmt.Errorf("failed to call contract: %w", err)
+		}
+		if onchain.NftContract == params.NFTContract &&
+			onchain.TokenId.Cmp(params.TokenID) == 0 &&
+			onchain.StartTime.Uint64() == header.Time {
+			return id - 1, onchain, nil
+		}
+	}
+	return 0, auctionState{}, fmt.Errorf("auction created by %s not found in contract", receipt.TxHash.Hex())
+}
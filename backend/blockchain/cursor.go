@@ -0,0 +1,122 @@
+package blockchain
+
+import (
+	"auction-backend/database"
+	"auction-backend/models"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// loadCursor 从数据库加载同步游标
+func (el *EventListener) loadCursor() error {
+	var cursor models.SyncCursor
+	err := database.GetDB().
+		Where("chain_id = ? AND contract_address = ?", el.chainID, el.contractKey()).
+		First(&cursor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		el.cursor = nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load sync cursor: %w", err)
+	}
+
+	el.cursor = &cursor
+	return nil
+}
+
+// resumeBlock 计算重启后需要开始扫描的区块号
+func (el *EventListener) resumeBlock() uint64 {
+	if el.cursor == nil {
+		return el.startBlock
+	}
+	// 区块内还有未处理完的日志时，从同一区块继续
+	if el.cursor.LogIndex >= 0 {
+		return el.cursor.BlockNumber
+	}
+	return el.cursor.BlockNumber + 1
+}
+
+// isProcessed 判断日志是否已被处理过（位于游标之前）
+func (el *EventListener) isProcessed(vLog types.Log) bool {
+	if el.cursor == nil {
+		return false
+	}
+	if vLog.BlockNumber != el.cursor.BlockNumber {
+		return vLog.BlockNumber < el.cursor.BlockNumber
+	}
+	return el.cursor.LogIndex < 0 || int(vLog.Index) <= el.cursor.LogIndex
+}
+
+// saveCursor 在事务中更新同步游标，logIndex 为 -1 表示整个区块已处理完
+func (el *EventListener) saveCursor(tx *gorm.DB, blockNumber uint64, logIndex int) error {
+	cursor := models.SyncCursor{
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		BlockNumber:     blockNumber,
+		LogIndex:        logIndex,
+		UpdatedAt:       time.Now(),
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_number", "log_index", "updated_at"}),
+	}).Create(&cursor).Error
+	if err != nil {
+		return fmt.Errorf("failed to save sync cursor: %w", err)
+	}
+	return nil
+}
+
+// advanceCursor 事务提交后同步内存中的游标
+func (el *EventListener) advanceCursor(blockNumber uint64, logIndex int) {
+	el.cursor = &models.SyncCursor{
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		BlockNumber:     blockNumber,
+		LogIndex:        logIndex,
+	}
+}
+
+// markBlockDone 标记截止到指定区块的日志已全部处理，blockHash 非空时记录该区块哈希用于重组检测
+func (el *EventListener) markBlockDone(blockNumber uint64, blockHash common.Hash) error {
+	if el.cursor != nil && el.cursor.BlockNumber > blockNumber {
+		return nil
+	}
+
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if blockHash != (common.Hash{}) {
+			if err := el.recordBlock(tx, blockNumber, blockHash); err != nil {
+				return err
+			}
+		}
+		return el.saveCursor(tx, blockNumber, -1)
+	})
+	if err != nil {
+		return err
+	}
+	el.advanceCursor(blockNumber, -1)
+
+	if err := el.pruneBlocks(blockNumber); err != nil {
+		log.Printf("Failed to prune indexed blocks: %v\n", err)
+	}
+	return nil
+}
+
+// contractKey 游标中使用的合约地址（小写）
+func (el *EventListener) contractKey() string {
+	return strings.ToLower(el.contractAddress.Hex())
+}
+
+// scope 按监听器的链和合约过滤拍卖、出价等业务数据
+func (el *EventListener) scope() func(*gorm.DB) *gorm.DB {
+	return chainContractScope(el.chainID, el.contractKey())
+}
@@ -0,0 +1,156 @@
+package blockchain
+
+import (
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+// ErrDeadLetterClosed 死信已被处理或丢弃
+var ErrDeadLetterClosed = errors.New("dead letter already closed")
+
+// newReplayListener 创建仅用于重放死信的监听器，重放日志时不连接节点
+func newReplayListener(chainID uint64, contractAddress string) (*EventListener, error) {
+	address := common.HexToAddress(contractAddress)
+	contractABI, filterer, err := newAuctionDecoder(address)
+	if err != nil {
+		return nil, err
+	}
+
+	el := &EventListener{
+		contractAddress: address,
+		contractABI:     contractABI,
+		filterer:        filterer,
+		chainID:         chainID,
+	}
+	// 使用注册表中固定的 ABI 版本，合约已不在注册表时按实现历史选择
+	if contract, err := LookupContract(chainID, contractAddress); err == nil {
+		el.abiVersion = contract.ABIVersion
+	}
+	return el, nil
+}
+
+// RetryDeadLetter 重新处理一条死信，成功后标记为已处理
+func RetryDeadLetter(id uint) (*models.DeadLetter, error) {
+	db := database.GetDB()
+
+	var letter models.DeadLetter
+	if err := db.First(&letter, id).Error; err != nil {
+		return nil, err
+	}
+	if letter.Status != models.DeadLetterPending {
+		return nil, fmt.Errorf("%w: dead letter %d is %s", ErrDeadLetterClosed, id, letter.Status)
+	}
+
+	el, err := newReplayListener(letter.ChainID, letter.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var apply func(tx *gorm.DB) error
+	if letter.Source == models.DeadLetterSourceCall {
+		if apply, err = el.prepareCallReplay(&letter); err != nil {
+			return nil, err
+		}
+	} else {
+		var vLog types.Log
+		if err := json.Unmarshal([]byte(letter.RawLog), &vLog); err != nil {
+			return nil, fmt.Errorf("failed to decode raw log: %w", err)
+		}
+		apply = func(tx *gorm.DB) error {
+			return el.applyOnce(tx, vLog, el.eventName(vLog))
+		}
+	}
+
+	now := time.Now()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := apply(tx); err != nil {
+			return err
+		}
+		return tx.Model(&letter).Updates(map[string]interface{}{
+			"status":      models.DeadLetterResolved,
+			"resolved_at": now,
+			"attempts":    gorm.Expr("attempts + 1"),
+		}).Error
+	})
+	if err != nil {
+		db.Model(&letter).Updates(map[string]interface{}{
+			"error":    err.Error(),
+			"attempts": gorm.Expr("attempts + 1"),
+		})
+		return nil, fmt.Errorf("retry failed: %w", err)
+	}
+
+	if err := db.First(&letter, id).Error; err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
+
+// prepareCallReplay 从节点重新读取交易调用所在的区块并解码，交易已不在原位置（如发生重组）时报错
+// 解码需要读取回执和历史状态，因此重放交易调用时监听器连接所在链的节点池
+func (el *EventListener) prepareCallReplay(letter *models.DeadLetter) (func(tx *gorm.DB) error, error) {
+	pool, err := GetRPCPool(letter.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	el.client = pool
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	block, err := pool.BlockByNumber(ctx, new(big.Int).SetUint64(letter.BlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", letter.BlockNumber, err)
+	}
+	txs := block.Transactions()
+	if int(letter.LogIndex) >= len(txs) || txs[letter.LogIndex].Hash().Hex() != letter.TxHash {
+		return nil, fmt.Errorf("transaction %s is no longer at index %d of block %d", letter.TxHash, letter.LogIndex, letter.BlockNumber)
+	}
+
+	call, err := el.decodeCall(ctx, block, txs[letter.LogIndex], letter.LogIndex)
+	if err != nil {
+		return nil, err
+	}
+	if call == nil {
+		return nil, fmt.Errorf("transaction %s is not an indexed call", letter.TxHash)
+	}
+	baseID, err := el.callBaseID(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	return func(tx *gorm.DB) error {
+		return el.applyCallOnce(tx, call, baseID)
+	}, nil
+}
+
+// DiscardDeadLetter 丢弃一条死信
+func DiscardDeadLetter(id uint) (*models.DeadLetter, error) {
+	db := database.GetDB()
+
+	var letter models.DeadLetter
+	if err := db.First(&letter, id).Error; err != nil {
+		return nil, err
+	}
+	if letter.Status != models.DeadLetterPending {
+		return nil, fmt.Errorf("%w: dead letter %d is %s", ErrDeadLetterClosed, id, letter.Status)
+	}
+
+	now := time.Now()
+	if err := db.Model(&letter).Updates(map[string]interface{}{
+		"status":      models.DeadLetterDiscarded,
+		"resolved_at": now,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to discard dead letter: %w", err)
+	}
+	return &letter, nil
+}
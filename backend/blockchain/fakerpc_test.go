@@ -0,0 +1,67 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// rpcHandler 模拟节点对单个 JSON-RPC 方法的响应
+type rpcHandler func(params []json.RawMessage) (interface{}, error)
+
+// newTestPool 创建连接到模拟节点的节点池，eth_chainId 固定返回 chainID
+func newTestPool(t *testing.T, chainID uint64, handlers map[string]rpcHandler) *RPCPool {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch handler, ok := handlers[req.Method]; {
+		case req.Method == "eth_chainId":
+			resp["result"] = hexutil.Uint64(chainID)
+		case !ok:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found: " + req.Method}
+		default:
+			result, err := handler(req.Params)
+			if err != nil {
+				resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+			} else {
+				resp["result"] = result
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	pool := newRPCPool(chainID, []string{server.URL})
+	t.Cleanup(func() {
+		for _, ep := range pool.endpoints {
+			if ep.client != nil {
+				ep.client.Close()
+			}
+		}
+	})
+	return pool
+}
+
+// setTestConfig 在测试期间替换全局配置
+func setTestConfig(t *testing.T, cfg config.Config) {
+	t.Helper()
+	prev := config.AppConfig
+	config.AppConfig = &cfg
+	t.Cleanup(func() { config.AppConfig = prev })
+}
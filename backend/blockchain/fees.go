@@ -0,0 +1,132 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// minBumpPercent 节点接受替换交易所需的最低费用比例（原费用的 110%）
+const minBumpPercent = 110
+
+// feeStrategy 小费倍数和 base fee 余量（百分比）
+type feeStrategy struct {
+	tipPercent     int64 // 在节点建议小费上的倍数
+	baseFeePercent int64 // maxFeePerGas 为 base fee 预留的倍数，用于抵御后续区块 base fee 上涨
+}
+
+var feeStrategies = map[string]feeStrategy{
+	"slow":     {tipPercent: 100, baseFeePercent: 125},
+	"standard": {tipPercent: 100, baseFeePercent: 200},
+	"fast":     {tipPercent: 150, baseFeePercent: 200},
+}
+
+// txFees 交易费用，DynamicFee 为 false 时只使用 GasPrice
+type txFees struct {
+	DynamicFee bool
+	GasPrice   *big.Int
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+}
+
+// suggestFees 根据最新区块计算交易费用，链未启用 London 时退回到传统 gas price
+func (cs *ContractService) suggestFees(ctx context.Context) (*txFees, error) {
+	header, err := cs.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	feeCap := gweiToWei(config.AppConfig.MaxFeePerGasGwei)
+
+	// 区块没有 base fee 说明链不支持 EIP-1559
+	if header.BaseFee == nil {
+		gasPrice, err := cs.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		if feeCap != nil && gasPrice.Cmp(feeCap) > 0 {
+			gasPrice = feeCap
+		}
+		return &txFees{GasPrice: gasPrice}, nil
+	}
+
+	strategy, ok := feeStrategies[config.AppConfig.FeeStrategy]
+	if !ok {
+		strategy = feeStrategies["standard"]
+	}
+
+	tip, err := cs.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas tip cap: %w", err)
+	}
+	tip = percentOf(tip, strategy.tipPercent)
+	if tipCap := gweiToWei(config.AppConfig.MaxPriorityFeeGwei); tipCap != nil && tip.Cmp(tipCap) > 0 {
+		tip = tipCap
+	}
+
+	maxFee := new(big.Int).Add(percentOf(header.BaseFee, strategy.baseFeePercent), tip)
+	if feeCap != nil && maxFee.Cmp(feeCap) > 0 {
+		// 上限低于当前 base fee 的交易无法被打包
+		if feeCap.Cmp(header.BaseFee) < 0 {
+			return nil, fmt.Errorf("max fee per gas %s is below current base fee %s", feeCap, header.BaseFee)
+		}
+		maxFee = feeCap
+	}
+	if tip.Cmp(maxFee) > 0 {
+		tip = new(big.Int).Set(maxFee)
+	}
+
+	return &txFees{
+		DynamicFee: true,
+		GasTipCap:  tip,
+		GasFeeCap:  maxFee,
+	}, nil
+}
+
+// txType 交易类型
+func (f *txFees) txType() uint8 {
+	if f.DynamicFee {
+		return types.DynamicFeeTxType
+	}
+	return types.LegacyTxType
+}
+
+// bump 将费用提高到不低于原交易费用的 percent%，节点要求替换交易的费用至少提高 10%，percent 低于 110 时按 110 计算
+func (f *txFees) bump(old *types.Transaction, percent int64) {
+	if percent < minBumpPercent {
+		percent = minBumpPercent
+	}
+	if f.DynamicFee {
+		f.GasTipCap = maxBig(f.GasTipCap, percentOf(old.GasTipCap(), percent))
+		f.GasFeeCap = maxBig(f.GasFeeCap, percentOf(old.GasFeeCap(), percent))
+		return
+	}
+	f.GasPrice = maxBig(f.GasPrice, percentOf(old.GasPrice(), percent))
+}
+
+// maxBig 返回较大值
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+// percentOf 计算 value * percent / 100
+func percentOf(value *big.Int, percent int64) *big.Int {
+	result := new(big.Int).Mul(value, big.NewInt(percent))
+	return result.Div(result, big.NewInt(100))
+}
+
+// gweiToWei 将配置中的 gwei 转换为 wei，0 表示不限制并返回 nil
+func gweiToWei(gwei float64) *big.Int {
+	if gwei <= 0 {
+		return nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
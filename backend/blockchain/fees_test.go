@@ -0,0 +1,235 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func gwei(n float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(n), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
+
+func TestSuggestFees(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseFee     *big.Int // nil 表示链不支持 EIP-1559
+		gasPrice    *big.Int
+		tip         *big.Int
+		strategy    string
+		maxFee      float64
+		maxPriority float64
+		want        *txFees
+		wantErr     bool
+	}{
+		{
+			name:     "legacy fallback without base fee",
+			gasPrice: gwei(30),
+			strategy: "standard",
+			want:     &txFees{GasPrice: gwei(30)},
+		},
+		{
+			name:     "legacy gas price capped",
+			gasPrice: gwei(30),
+			strategy: "standard",
+			maxFee:   20,
+			want:     &txFees{GasPrice: gwei(20)},
+		},
+		{
+			name:     "standard",
+			baseFee:  gwei(10),
+			tip:      gwei(2),
+			strategy: "standard",
+			want:     &txFees{DynamicFee: true, GasTipCap: gwei(2), GasFeeCap: gwei(22)},
+		},
+		{
+			name:     "slow",
+			baseFee:  gwei(10),
+			tip:      gwei(2),
+			strategy: "slow",
+			want:     &txFees{DynamicFee: true, GasTipCap: gwei(2), GasFeeCap: gwei(14.5)},
+		},
+		{
+			name:     "fast",
+			baseFee:  gwei(10),
+			tip:      gwei(2),
+			strategy: "fast",
+			want:     &txFees{DynamicFee: true, GasTipCap: gwei(3), GasFeeCap: gwei(23)},
+		},
+		{
+			name:     "unknown strategy uses standard",
+			baseFee:  gwei(10),
+			tip:      gwei(2),
+			strategy: "turbo",
+			want:     &txFees{DynamicFee: true, GasTipCap: gwei(2), GasFeeCap: gwei(22)},
+		},
+		{
+			name:        "priority fee capped",
+			baseFee:     gwei(10),
+			tip:         gwei(2),
+			strategy:    "standard",
+			maxPriority: 1,
+			want:        &txFees{DynamicFee: true, GasTipCap: gwei(1), GasFeeCap: gwei(21)},
+		},
+		{
+			name:     "max fee capped above base fee",
+			baseFee:  gwei(10),
+			tip:      gwei(2),
+			strategy: "standard",
+			maxFee:   15,
+			want:     &txFees{DynamicFee: true, GasTipCap: gwei(2), GasFeeCap: gwei(15)},
+		},
+		{
+			name:     "tip limited to capped max fee",
+			baseFee:  gwei(10),
+			tip:      gwei(20),
+			strategy: "standard",
+			maxFee:   12,
+			want:     &txFees{DynamicFee: true, GasTipCap: gwei(12), GasFeeCap: gwei(12)},
+		},
+		{
+			name:     "max fee below base fee",
+			baseFee:  gwei(10),
+			tip:      gwei(2),
+			strategy: "standard",
+			maxFee:   5,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t, config.Config{
+				FeeStrategy:        tt.strategy,
+				MaxFeePerGasGwei:   tt.maxFee,
+				MaxPriorityFeeGwei: tt.maxPriority,
+			})
+			header := &types.Header{Number: big.NewInt(100), Difficulty: big.NewInt(0), BaseFee: tt.baseFee}
+			pool := newTestPool(t, 1, map[string]rpcHandler{
+				"eth_getBlockByNumber": func([]json.RawMessage) (interface{}, error) {
+					return header, nil
+				},
+				"eth_gasPrice": func([]json.RawMessage) (interface{}, error) {
+					return (*hexutil.Big)(tt.gasPrice), nil
+				},
+				"eth_maxPriorityFeePerGas": func([]json.RawMessage) (interface{}, error) {
+					return (*hexutil.Big)(tt.tip), nil
+				},
+			})
+			cs := &ContractService{client: pool, chainID: 1}
+
+			got, err := cs.suggestFees(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("suggestFees() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("suggestFees() error = %v", err)
+			}
+			assertFees(t, got, tt.want)
+		})
+	}
+}
+
+func TestTxFeesBump(t *testing.T) {
+	dynamicOld := types.NewTx(&types.DynamicFeeTx{GasTipCap: gwei(2), GasFeeCap: gwei(20)})
+	legacyOld := types.NewTx(&types.LegacyTx{GasPrice: gwei(10)})
+
+	tests := []struct {
+		name    string
+		fees    *txFees
+		old     *types.Transaction
+		percent int64
+		want    *txFees
+	}{
+		{
+			name:    "dynamic fees raised to percent of old",
+			fees:    &txFees{DynamicFee: true, GasTipCap: gwei(1), GasFeeCap: gwei(10)},
+			old:     dynamicOld,
+			percent: 125,
+			want:    &txFees{DynamicFee: true, GasTipCap: gwei(2.5), GasFeeCap: gwei(25)},
+		},
+		{
+			name:    "higher current fees kept",
+			fees:    &txFees{DynamicFee: true, GasTipCap: gwei(5), GasFeeCap: gwei(40)},
+			old:     dynamicOld,
+			percent: 125,
+			want:    &txFees{DynamicFee: true, GasTipCap: gwei(5), GasFeeCap: gwei(40)},
+		},
+		{
+			name:    "bump below 110 percent raised to minimum",
+			fees:    &txFees{DynamicFee: true, GasTipCap: gwei(1), GasFeeCap: gwei(10)},
+			old:     dynamicOld,
+			percent: 105,
+			want:    &txFees{DynamicFee: true, GasTipCap: gwei(2.2), GasFeeCap: gwei(22)},
+		},
+		{
+			name:    "legacy gas price",
+			fees:    &txFees{GasPrice: gwei(5)},
+			old:     legacyOld,
+			percent: 125,
+			want:    &txFees{GasPrice: gwei(12.5)},
+		},
+		{
+			name:    "legacy bump of zero percent raised to minimum",
+			fees:    &txFees{GasPrice: gwei(5)},
+			old:     legacyOld,
+			percent: 0,
+			want:    &txFees{GasPrice: gwei(11)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fees.bump(tt.old, tt.percent)
+			assertFees(t, tt.fees, tt.want)
+		})
+	}
+}
+
+func TestGweiToWei(t *testing.T) {
+	tests := []struct {
+		gwei float64
+		want *big.Int
+	}{
+		{gwei: 0, want: nil},
+		{gwei: -1, want: nil},
+		{gwei: 1, want: big.NewInt(1_000_000_000)},
+		{gwei: 1.5, want: big.NewInt(1_500_000_000)},
+	}
+
+	for _, tt := range tests {
+		got := gweiToWei(tt.gwei)
+		if (got == nil) != (tt.want == nil) || (got != nil && got.Cmp(tt.want) != 0) {
+			t.Errorf("gweiToWei(%v) = %v, want %v", tt.gwei, got, tt.want)
+		}
+	}
+}
+
+func assertFees(t *testing.T, got, want *txFees) {
+	t.Helper()
+	if got.DynamicFee != want.DynamicFee {
+		t.Fatalf("DynamicFee = %v, want %v", got.DynamicFee, want.DynamicFee)
+	}
+	for _, field := range []struct {
+		name      string
+		got, want *big.Int
+	}{
+		{"GasPrice", got.GasPrice, want.GasPrice},
+		{"GasTipCap", got.GasTipCap, want.GasTipCap},
+		{"GasFeeCap", got.GasFeeCap, want.GasFeeCap},
+	} {
+		if (field.got == nil) != (field.want == nil) || (field.got != nil && field.got.Cmp(field.want) != 0) {
+			t.Errorf("%s = %v, want %v", field.name, field.got, field.want)
+		}
+	}
+}
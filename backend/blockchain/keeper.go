@@ -0,0 +1,395 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// keeperLeaseName 自动结束拍卖的租约名称
+	keeperLeaseName = "keeper"
+	// maxKeeperRetryDelay 重试间隔上限
+	maxKeeperRetryDelay = time.Hour
+)
+
+// ErrKeeperJobActive 任务仍在处理中
+var ErrKeeperJobActive = errors.New("keeper job is still active")
+
+// keepers 注册表中各合约的自动结束拍卖实例，按 IndexerKey 索引
+var (
+	keepersMu sync.RWMutex
+	keepers   = make(map[string]*Keeper)
+)
+
+// keeperLeases 同一条链上各 Keeper 共用的租约及本进程内的引用计数
+// 同一进程的 Keeper 持有者标识相同，只有最后一个退出的 Keeper 才释放租约，避免仍在提交交易的 Keeper 失去租约
+var (
+	keeperLeasesMu sync.Mutex
+	keeperLeases   = make(map[uint64]*sharedLease)
+)
+
+// sharedLease 被多个 Keeper 引用的租约
+type sharedLease struct {
+	lease *Lease
+	refs  int
+}
+
+// Keeper 自动结束已到期的拍卖，以服务端签名账户提交 endAuction
+// 多实例部署时通过数据库租约保证只有一个实例提交交易；同一条链上的合约共用签名账户，因此按链共用一个租约
+type Keeper struct {
+	contract *ContractService
+	lease    *Lease // Run 期间引用的链租约
+}
+
+// NewKeeper 创建自动结束拍卖实例
+func NewKeeper(contract *ContractService) *Keeper {
+	return &Keeper{contract: contract}
+}
+
+// retainKeeperLease 引用链的 Keeper 租约，不存在时创建
+func retainKeeperLease(chainID uint64) *Lease {
+	keeperLeasesMu.Lock()
+	defer keeperLeasesMu.Unlock()
+
+	shared, ok := keeperLeases[chainID]
+	if !ok {
+		ttl := time.Duration(config.AppConfig.KeeperLeaseTTL) * time.Second
+		shared = &sharedLease{lease: NewLease(fmt.Sprintf("%s:%d", keeperLeaseName, chainID), ttl)}
+		keeperLeases[chainID] = shared
+	}
+	shared.refs++
+	return shared.lease
+}
+
+// releaseKeeperLease 释放对链租约的引用，最后一个引用释放时让出租约
+func releaseKeeperLease(chainID uint64) error {
+	keeperLeasesMu.Lock()
+	defer keeperLeasesMu.Unlock()
+
+	shared, ok := keeperLeases[chainID]
+	if !ok {
+		return nil
+	}
+	if shared.refs--; shared.refs > 0 {
+		return nil
+	}
+	delete(keeperLeases, chainID)
+	return shared.lease.Release()
+}
+
+// SetKeeper 登记合约的自动结束拍卖实例
+func SetKeeper(k *Keeper) {
+	keepersMu.Lock()
+	defer keepersMu.Unlock()
+	keepers[IndexerKey(k.contract.chainID, k.contract.contractKey())] = k
+}
+
+// GetKeeper 获取链上合约的自动结束拍卖实例，未启动时返回 nil
+func GetKeeper(chainID uint64, address string) *Keeper {
+	keepersMu.RLock()
+	defer keepersMu.RUnlock()
+	return keepers[IndexerKey(chainID, address)]
+}
+
+// removeKeeper 注销合约的自动结束拍卖实例
+func removeKeeper(key string) {
+	keepersMu.Lock()
+	defer keepersMu.Unlock()
+	delete(keepers, key)
+}
+
+// Run 定期扫描并结束到期拍卖，直到 ctx 取消
+func (k *Keeper) Run(ctx context.Context) {
+	interval := time.Duration(config.AppConfig.KeeperInterval) * time.Second
+	if interval <= 0 {
+		log.Println("Auction keeper disabled")
+		return
+	}
+	if k.contract.signer == nil {
+		log.Println("Auction keeper disabled: operator signer is not configured")
+		return
+	}
+
+	k.lease = retainKeeperLease(k.contract.chainID)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := releaseKeeperLease(k.contract.chainID); err != nil {
+			log.Printf("Failed to release keeper lease: %v\n", err)
+		}
+	}()
+
+	for {
+		if err := k.tick(ctx); err != nil {
+			log.Printf("Auction keeper error: %v\n", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tick 持有租约时为到期拍卖建立任务并处理到期的任务
+// 每个任务前续约，租约丢失时立即停止，单个任务的耗时限制在租约时长的一半以内，避免租约过期后其他实例重复提交
+func (k *Keeper) tick(ctx context.Context) error {
+	held, err := k.lease.Acquire()
+	if err != nil {
+		return err
+	}
+	if !held {
+		return nil
+	}
+
+	// 合约以区块时间判断拍卖是否到期
+	head, err := k.contract.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if err := k.scheduleExpired(head.Time); err != nil {
+		return err
+	}
+
+	var jobs []models.KeeperJob
+	if err := database.GetDB().
+		Scopes(k.contract.scope()).
+		Where(database.GetDB().
+			Where("status = ?", models.KeeperJobSubmitted).
+			Or("status = ? AND next_attempt_at <= ?", models.KeeperJobPending, time.Now())).
+		Order("auction_id ASC").
+		Find(&jobs).Error; err != nil {
+		return fmt.Errorf("failed to load keeper jobs: %w", err)
+	}
+
+	for i := range jobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		held, err := k.lease.Acquire()
+		if err != nil {
+			return err
+		}
+		if !held {
+			log.Printf("Keeper lease lost, stopping before auction %d\n", jobs[i].AuctionID)
+			return nil
+		}
+
+		jobCtx, cancel := context.WithTimeout(ctx, k.lease.ttl/2)
+		err = k.process(jobCtx, &jobs[i])
+		cancel()
+		if err != nil {
+			log.Printf("Failed to process keeper job for auction %d: %v\n", jobs[i].AuctionID, err)
+		}
+	}
+	return nil
+}
+
+// scheduleExpired 为已到期但未结束的拍卖建立任务，已有任务的拍卖不重复建立
+func (k *Keeper) scheduleExpired(blockTime uint64) error {
+	db := database.GetDB()
+
+	var auctionIDs []uint
+	if err := db.Model(&models.Auction{}).
+		Scopes(k.contract.scope()).
+		Where("ended = ? AND start_time + duration <= ?", false, blockTime).
+		Pluck("auction_id", &auctionIDs).Error; err != nil {
+		return fmt.Errorf("failed to find expired auctions: %w", err)
+	}
+	if len(auctionIDs) == 0 {
+		return nil
+	}
+
+	jobs := make([]models.KeeperJob, 0, len(auctionIDs))
+	for _, id := range auctionIDs {
+		jobs = append(jobs, models.KeeperJob{
+			ChainID:         k.contract.chainID,
+			ContractAddress: k.contract.contractKey(),
+			AuctionID:       id,
+			Status:          models.KeeperJobPending,
+			NextAttemptAt:   time.Now(),
+		})
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&jobs).Error; err != nil {
+		return fmt.Errorf("failed to create keeper jobs: %w", err)
+	}
+	return nil
+}
+
+// process 推进单个任务：已提交的检查交易状态，待提交的提交 endAuction
+func (k *Keeper) process(ctx context.Context, job *models.KeeperJob) error {
+	if job.Status == models.KeeperJobSubmitted {
+		return k.checkSubmitted(ctx, job)
+	}
+
+	// 拍卖已在本地标记结束，说明被其他人的交易结束
+	var auction models.Auction
+	if err := database.GetDB().Scopes(chainContractScope(job.ChainID, job.ContractAddress)).
+		Where("auction_id = ?", job.AuctionID).
+		First(&auction).Error; err != nil {
+		return fmt.Errorf("failed to find auction: %w", err)
+	}
+	if auction.Ended {
+		return k.finish(job, models.KeeperJobSkipped, "")
+	}
+	return k.submit(ctx, job)
+}
+
+// submit 提交 endAuction，模拟执行被拒绝时不再重试
+func (k *Keeper) submit(ctx context.Context, job *models.KeeperJob) error {
+	auctionID := new(big.Int).SetUint64(uint64(job.AuctionID))
+	job.Attempts++
+
+	tx, err := k.contract.Transact(ctx, big.NewInt(0), "endAuction", auctionID)
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		// 合约中已结束时交给监听器和对账更新本地数据
+		if k.endedOnChain(ctx, job.AuctionID) {
+			return k.finish(job, models.KeeperJobSkipped, revertErr.Reason)
+		}
+		log.Printf("Keeper endAuction for auction %d reverted: %s\n", job.AuctionID, revertErr.Reason)
+		return k.finish(job, models.KeeperJobReverted, revertErr.Reason)
+	}
+	if err != nil {
+		return k.retry(job, err.Error())
+	}
+
+	now := time.Now()
+	job.Status = models.KeeperJobSubmitted
+	job.TxHash = tx.Hash().Hex()
+	job.SubmittedAt = &now
+	job.LastError = ""
+	log.Printf("Keeper submitted endAuction for auction %d: %s\n", job.AuctionID, job.TxHash)
+	return k.save(job)
+}
+
+// checkSubmitted 根据交易跟踪记录推进已提交的任务，长时间未打包时提高费用重新发送
+func (k *Keeper) checkSubmitted(ctx context.Context, job *models.KeeperJob) error {
+	var txn models.Transaction
+	err := database.GetDB().Where("tx_hash = ?", job.TxHash).First(&txn).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to load transaction: %w", err)
+	}
+
+	// 跟踪记录缺失时按待打包处理，由节点判断交易是否仍在交易池中
+	status := models.TxStatusPending
+	if err == nil {
+		status = txn.Status
+	}
+
+	switch status {
+	case models.TxStatusMined:
+		log.Printf("Keeper ended auction %d in %s\n", job.AuctionID, job.TxHash)
+		return k.finish(job, models.KeeperJobSucceeded, "")
+	case models.TxStatusFailed:
+		return k.retry(job, "transaction "+job.TxHash+" failed on chain")
+	case models.TxStatusReplaced, models.TxStatusDropped:
+		// 提高费用前的旧交易可能先被打包，此时新交易会被标记为已替代
+		if k.endedOnChain(ctx, job.AuctionID) {
+			return k.finish(job, models.KeeperJobSucceeded, "")
+		}
+		return k.retry(job, "transaction "+job.TxHash+" was "+status)
+	}
+
+	bumpAfter := time.Duration(config.AppConfig.KeeperBumpAfter) * time.Second
+	if job.SubmittedAt == nil || time.Since(*job.SubmittedAt) < bumpAfter {
+		return nil
+	}
+
+	auctionID := new(big.Int).SetUint64(uint64(job.AuctionID))
+	tx, err := k.contract.ReplaceTransaction(ctx, common.HexToHash(job.TxHash),
+		config.AppConfig.KeeperBumpPercent, "endAuction", auctionID)
+	if errors.Is(err, ErrTxNotPending) {
+		// 交易刚被打包或已被丢弃，等待跟踪器更新状态
+		return nil
+	}
+	if err != nil {
+		job.LastError = err.Error()
+		return k.save(job)
+	}
+
+	now := time.Now()
+	log.Printf("Keeper replaced %s for auction %d with %s\n", job.TxHash, job.AuctionID, tx.Hash().Hex())
+	job.TxHash = tx.Hash().Hex()
+	job.SubmittedAt = &now
+	return k.save(job)
+}
+
+// endedOnChain 合约中拍卖是否已结束，读取失败时视为未结束
+func (k *Keeper) endedOnChain(ctx context.Context, auctionID uint) bool {
+	info, err := k.contract.auction.Auctions(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(uint64(auctionID)))
+	return err == nil && info.Isend
+}
+
+// retry 记录失败原因并按指数退避安排重试，达到最大次数后标记为失败
+func (k *Keeper) retry(job *models.KeeperJob, reason string) error {
+	if job.Attempts >= config.AppConfig.KeeperMaxAttempts {
+		log.Printf("Keeper gave up on auction %d after %d attempts: %s\n", job.AuctionID, job.Attempts, reason)
+		return k.finish(job, models.KeeperJobFailed, reason)
+	}
+
+	delay := time.Duration(config.AppConfig.KeeperRetryDelay) * time.Second
+	for i := 1; i < job.Attempts && delay < maxKeeperRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxKeeperRetryDelay {
+		delay = maxKeeperRetryDelay
+	}
+
+	job.Status = models.KeeperJobPending
+	job.LastError = reason
+	job.NextAttemptAt = time.Now().Add(delay)
+	return k.save(job)
+}
+
+// finish 结束任务
+func (k *Keeper) finish(job *models.KeeperJob, status, reason string) error {
+	now := time.Now()
+	job.Status = status
+	job.LastError = reason
+	job.FinishedAt = &now
+	return k.save(job)
+}
+
+// save 保存任务状态
+func (k *Keeper) save(job *models.KeeperJob) error {
+	if err := database.GetDB().Save(job).Error; err != nil {
+		return fmt.Errorf("failed to save keeper job: %w", err)
+	}
+	return nil
+}
+
+// RetryKeeperJob 将已结束的任务重新置为待提交，用于排除问题后手动重试
+func RetryKeeperJob(id uint) (*models.KeeperJob, error) {
+	db := database.GetDB()
+	var job models.KeeperJob
+	if err := db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	if job.Status == models.KeeperJobPending || job.Status == models.KeeperJobSubmitted {
+		return nil, ErrKeeperJobActive
+	}
+
+	job.Status = models.KeeperJobPending
+	job.Attempts = 0
+	job.NextAttemptAt = time.Now()
+	job.FinishedAt = nil
+	if err := db.Save(&job).Error; err != nil {
+		return nil, fmt.Errorf("failed to save keeper job: %w", err)
+	}
+	return &job, nil
+}
@@ -0,0 +1,86 @@
+package blockchain
+
+import (
+	"auction-backend/database"
+	"auction-backend/models"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Lease 基于数据库的租约，多实例部署时同一时间只有一个实例持有
+// 持有者需在过期前续约，实例崩溃后租约过期即可被其他实例接管
+type Lease struct {
+	name   string
+	holder string
+	ttl    time.Duration
+}
+
+// NewLease 创建租约，持有者标识为主机名和进程号
+func NewLease(name string, ttl time.Duration) *Lease {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return &Lease{
+		name:   name,
+		holder: fmt.Sprintf("%s-%d", host, os.Getpid()),
+		ttl:    ttl,
+	}
+}
+
+// Holder 本实例的持有者标识
+func (l *Lease) Holder() string {
+	return l.holder
+}
+
+// Acquire 获取或续约，返回本实例是否持有租约
+func (l *Lease) Acquire() (bool, error) {
+	db := database.GetDB()
+	now := time.Now()
+	expiresAt := now.Add(l.ttl)
+
+	// 租约不存在时直接创建
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Lease{
+		Name:      l.name,
+		Holder:    l.holder,
+		ExpiresAt: expiresAt,
+	})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create lease: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// 自己持有时续约，其他实例的租约已过期时接管
+	if err := db.Model(&models.Lease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", l.name, l.holder, now).
+		Updates(map[string]interface{}{"holder": l.holder, "expires_at": expiresAt}).Error; err != nil {
+		return false, fmt.Errorf("failed to renew lease: %w", err)
+	}
+
+	// 值未变化时 MySQL 不计入影响行数，以重新读取的结果为准
+	var lease models.Lease
+	if err := db.Where("name = ?", l.name).First(&lease).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to load lease: %w", err)
+	}
+	return lease.Holder == l.holder && lease.ExpiresAt.After(now), nil
+}
+
+// Release 释放本实例持有的租约，让其他实例无需等待过期即可接管
+func (l *Lease) Release() error {
+	if err := database.GetDB().Model(&models.Lease{}).
+		Where("name = ? AND holder = ?", l.name, l.holder).
+		Update("expires_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	return nil
+}
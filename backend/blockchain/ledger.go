@@ -0,0 +1,45 @@
+package blockchain
+
+import (
+	"auction-backend/models"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ignoredEventName 账本中不属于拍卖合约 ABI 的日志（如代理合约的 OwnershipTransferred、Initialized）使用的事件名
+const ignoredEventName = "ignored"
+
+// recordEvent 在事件账本中登记日志，返回 false 表示该日志此前已被应用
+func (el *EventListener) recordEvent(tx *gorm.DB, vLog types.Log, eventName string) (bool, error) {
+	return el.recordLedger(tx, vLog.TxHash, vLog.Index, vLog.BlockNumber, vLog.BlockHash, eventName)
+}
+
+// recordLedger 登记账本条目，调用解码模式下 index 为交易在区块中的序号
+func (el *EventListener) recordLedger(tx *gorm.DB, txHash common.Hash, index uint, blockNumber uint64, blockHash common.Hash, name string) (bool, error) {
+	event := models.Event{
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		TxHash:          txHash.Hex(),
+		LogIndex:        index,
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash.Hex(),
+		EventName:       name,
+	}
+	return insertLedger(tx, &event)
+}
+
+// identityColumns 账本、出价和死信的唯一键：不同合约可能处理同一交易中相同序号的日志或调用
+var identityColumns = []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "tx_hash"}, {Name: "log_index"}}
+
+// insertLedger 写入账本条目，已存在时忽略
+func insertLedger(tx *gorm.DB, event *models.Event) (bool, error) {
+	result := tx.Clauses(clause.OnConflict{Columns: identityColumns, DoNothing: true}).Create(event)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record event: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -7,90 +7,93 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math/big"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// NftAuction 合约 ABI (只包含事件部分)
-const NftAuctionABI = `[
-	{
-		"anonymous": false,
-		"inputs": [
-			{"indexed": true, "internalType": "uint256", "name": "auctionId", "type": "uint256"},
-			{"indexed": true, "internalType": "address", "name": "seller", "type": "address"},
-			{"indexed": true, "internalType": "address", "name": "nftContract", "type": "address"},
-			{"indexed": false, "internalType": "uint256", "name": "tokenId", "type": "uint256"},
-			{"indexed": false, "internalType": "uint256", "name": "startPrice", "type": "uint256"},
-			{"indexed": false, "internalType": "uint256", "name": "duration", "type": "uint256"},
-			{"indexed": false, "internalType": "uint256", "name": "startTime", "type": "uint256"}
-		],
-		"name": "AuctionCreated",
-		"type": "event"
-	},
-	{
-		"anonymous": false,
-		"inputs": [
-			{"indexed": true, "internalType": "uint256", "name": "auctionId", "type": "uint256"},
-			{"indexed": true, "internalType": "address", "name": "bidder", "type": "address"},
-			{"indexed": false, "internalType": "uint256", "name": "amount", "type": "uint256"},
-			{"indexed": false, "internalType": "address", "name": "tokenAddress", "type": "address"},
-			{"indexed": false, "internalType": "uint256", "name": "timestamp", "type": "uint256"}
-		],
-		"name": "BidPlaced",
-		"type": "event"
-	},
-	{
-		"anonymous": false,
-		"inputs": [
-			{"indexed": true, "internalType": "uint256", "name": "auctionId", "type": "uint256"},
-			{"indexed": true, "internalType": "address", "name": "winner", "type": "address"},
-			{"indexed": false, "internalType": "uint256", "name": "finalPrice", "type": "uint256"},
-			{"indexed": false, "internalType": "address", "name": "tokenAddress", "type": "address"},
-			{"indexed": false, "internalType": "uint256", "name": "timestamp", "type": "uint256"}
-		],
-		"name": "AuctionEnded",
-		"type": "event"
-	}
-]`
+// NftAuction 合约绑定由 abi/NFTAuction.abi.json 生成，ABI 变更后执行 go generate 重新生成
+//go:generate abigen --abi abi/NFTAuction.abi.json --pkg blockchain --type NftAuction --out nft_auction.go
 
 type EventListener struct {
-	client          *ethclient.Client
+	client          *RPCPool // 合约所在链的节点池
 	contractAddress common.Address
 	contractABI     abi.ABI
+	filterer        *NftAuctionFilterer // 合约事件解析
+	chainID         uint64
+	startBlock      uint64             // 首次同步的起始区块
+	abiVersion      string             // 注册表中固定的 ABI 版本，为空时按实现历史选择
+	cursor          *models.SyncCursor // 已处理到的位置，nil 表示尚未同步过
+	chunkSize       uint64             // 当前每次 FilterLogs 查询的区块跨度
+
+	implementations []models.Implementation // 实现历史缓存，按生效区块排序
+	implLoaded      bool                    // 实现历史缓存是否有效
+
+	progressMu sync.RWMutex
+	progress   SyncProgress
 }
 
-// NewEventListener 创建事件监听器
-func NewEventListener() (*EventListener, error) {
-	client, err := ethclient.Dial(config.AppConfig.ETHRPCURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to ethereum client: %w", err)
+// NewEventListener 为注册表中的合约创建事件监听器，pool 为合约所在链的节点池
+func NewEventListener(pool *RPCPool, contract *models.Contract) (*EventListener, error) {
+	if pool.chainID != contract.ChainID {
+		return nil, fmt.Errorf("RPC pool for chain %d cannot index contract on chain %d", pool.chainID, contract.ChainID)
 	}
 
-	contractABI, err := abi.JSON(strings.NewReader(NftAuctionABI))
+	contractAddress := common.HexToAddress(contract.Address)
+	contractABI, filterer, err := newAuctionDecoder(contractAddress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse contract ABI: %w", err)
+		return nil, err
 	}
 
 	return &EventListener{
-		client:          client,
-		contractAddress: common.HexToAddress(config.AppConfig.ContractAddress),
+		client:          pool,
+		contractAddress: contractAddress,
 		contractABI:     contractABI,
+		filterer:        filterer,
+		chainID:         contract.ChainID,
+		startBlock:      contract.StartBlock,
+		abiVersion:      contract.ABIVersion,
+		chunkSize:       config.AppConfig.LogChunkSize,
 	}, nil
 }
 
+// newAuctionDecoder 创建拍卖合约的 ABI 和事件解析器
+func newAuctionDecoder(contractAddress common.Address) (abi.ABI, *NftAuctionFilterer, error) {
+	contractABI, err := NftAuctionMetaData.GetAbi()
+	if err != nil {
+		return abi.ABI{}, nil, fmt.Errorf("failed to parse contract ABI: %w", err)
+	}
+
+	// 解析日志只用到 ABI，不需要节点连接
+	filterer, err := NewNftAuctionFilterer(contractAddress, nil)
+	if err != nil {
+		return abi.ABI{}, nil, fmt.Errorf("failed to bind contract: %w", err)
+	}
+	return *contractABI, filterer, nil
+}
+
 // StartListening 开始监听事件
 func (el *EventListener) StartListening(ctx context.Context) error {
-	log.Println("Starting event listener...")
+	log.Printf("Starting event listener for %s on chain %d...\n", el.contractKey(), el.chainID)
+
+	// 从同步游标恢复已处理的区块号
+	if err := el.loadCursor(); err != nil {
+		return err
+	}
+
+	// 合约不发事件时改为解码交易调用或读取合约状态
+	if config.AppConfig.IndexMode != IndexModeEvents {
+		return el.pollCalls(ctx)
+	}
+	startBlock := el.resumeBlock()
 
-	// 获取最新已处理的区块号
-	startBlock := config.AppConfig.StartBlock
-	
 	// 订阅新区块
 	query := ethereum.FilterQuery{
 		Addresses: []common.Address{el.contractAddress},
@@ -101,10 +104,29 @@ func (el *EventListener) StartListening(ctx context.Context) error {
 	if err != nil {
 		// 如果订阅失败，使用轮询方式
 		log.Println("Subscribe failed, using polling mode...")
-		return el.pollLogs(ctx, startBlock)
+		return el.pollLogs(ctx)
+	}
+	defer sub.Unsubscribe()
+
+	// 停机期间可能发生过链重组，补齐前先校验已记录的区块
+	if err := el.checkReorg(ctx); err != nil {
+		return err
+	}
+	startBlock = el.resumeBlock()
+
+	// 先订阅再补齐历史，补齐期间产生的新日志会在订阅通道中排队，已处理的会被游标跳过
+	if err := el.backfill(ctx, startBlock); err != nil {
+		return err
+	}
+	if err := el.checkImplementation(ctx); err != nil {
+		log.Printf("Implementation check failed: %v\n", err)
 	}
 
 	log.Println("Event listener started successfully")
+	el.setMode("subscription")
+
+	reorgTicker := time.NewTicker(reorgCheckInterval)
+	defer reorgTicker.Stop()
 
 	for {
 		select {
@@ -112,7 +134,26 @@ func (el *EventListener) StartListening(ctx context.Context) error {
 			log.Printf("Subscription error: %v\n", err)
 			return err
 		case vLog := <-logs:
-			el.handleLog(vLog)
+			handled, err := el.checkLogReorg(ctx, vLog)
+			if err != nil {
+				// 不能跳过该日志，否则后续日志推进游标后它再也不会被处理；由守护重启后从游标处重放
+				return fmt.Errorf("failed to handle chain reorganization: %w", err)
+			}
+			if !handled {
+				if err := el.handleLog(vLog); err != nil {
+					return err
+				}
+			}
+		case <-reorgTicker.C:
+			if err := el.checkReorg(ctx); err != nil {
+				log.Printf("Reorg check failed: %v\n", err)
+			}
+			if err := el.checkImplementation(ctx); err != nil {
+				log.Printf("Implementation check failed: %v\n", err)
+			}
+			if err := el.confirmBlocks(ctx); err != nil {
+				log.Printf("Failed to confirm blocks: %v\n", err)
+			}
 		case <-ctx.Done():
 			log.Println("Event listener stopped")
 			return nil
@@ -120,195 +161,225 @@ func (el *EventListener) StartListening(ctx context.Context) error {
 	}
 }
 
-// pollLogs 轮询方式获取日志
-func (el *EventListener) pollLogs(ctx context.Context, fromBlock uint64) error {
-	query := ethereum.FilterQuery{
-		FromBlock: big.NewInt(int64(fromBlock)),
-		Addresses: []common.Address{el.contractAddress},
+// handleLog 处理单个日志，临时性错误按退避重试，无法处理的日志被搁置后继续推进游标
+// 重试耗尽仍失败时返回错误，由守护重启监听器并从游标处重新处理
+func (el *EventListener) handleLog(vLog types.Log) error {
+	if el.isProcessed(vLog) {
+		return nil
 	}
 
-	logs, err := el.client.FilterLogs(ctx, query)
-	if err != nil {
-		return fmt.Errorf("failed to filter logs: %w", err)
-	}
+	eventName := el.eventName(vLog)
 
-	for _, vLog := range logs {
-		el.handleLog(vLog)
+	var err error
+	for attempt := 1; attempt <= maxApplyAttempts; attempt++ {
+		if err = el.applyLog(vLog, eventName); err == nil {
+			el.advanceCursor(vLog.BlockNumber, int(vLog.Index))
+			return nil
+		}
+		if isPermanentError(err) {
+			return el.parkLog(vLog, eventName, err)
+		}
+
+		log.Printf("Failed to process log %s#%d (attempt %d/%d): %v\n",
+			vLog.TxHash.Hex(), vLog.Index, attempt, maxApplyAttempts, err)
+		if attempt < maxApplyAttempts {
+			time.Sleep(applyRetryBackoff << (attempt - 1))
+		}
 	}
 
-	return nil
+	return fmt.Errorf("failed to process log %s#%d: %w", vLog.TxHash.Hex(), vLog.Index, err)
 }
 
-// handleLog 处理单个日志
-func (el *EventListener) handleLog(vLog types.Log) {
-	eventName := ""
-	for name, event := range el.contractABI.Events {
+// eventName 根据 topic 查找事件名，按日志所在区块生效的实现版本解码，未知事件返回空字符串
+func (el *EventListener) eventName(vLog types.Log) string {
+	if len(vLog.Topics) == 0 {
+		return ""
+	}
+	if el.isUpgradeLog(vLog) {
+		return "Upgraded"
+	}
+	for name, event := range el.abiAt(vLog.BlockNumber).Events {
 		if event.ID == vLog.Topics[0] {
-			eventName = name
-			break
+			return name
 		}
 	}
+	return ""
+}
 
+// applyLog 在单个事务中应用日志：事件账本、业务数据、区块哈希和同步游标要么全部写入，要么全部回滚
+func (el *EventListener) applyLog(vLog types.Log, eventName string) error {
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := el.applyOnce(tx, vLog, eventName); err != nil {
+			return err
+		}
+		if err := el.recordBlock(tx, vLog.BlockNumber, vLog.BlockHash); err != nil {
+			return err
+		}
+		return el.saveCursor(tx, vLog.BlockNumber, int(vLog.Index))
+	})
+}
+
+// applyOnce 通过事件账本保证同一日志只被应用一次，重放任意区块区间结果一致
+// 不属于任何版本拍卖合约 ABI 的日志在账本中登记为已忽略；签名已知但按当前实现版本无法识别的日志视为解码失败
+func (el *EventListener) applyOnce(tx *gorm.DB, vLog types.Log, eventName string) error {
+	if eventName == "" {
+		if len(vLog.Topics) > 0 && isAuctionEventTopic(vLog.Topics[0]) {
+			return fmt.Errorf("%w: event %s is not in the ABI active at block %d",
+				errDecode, vLog.Topics[0].Hex(), vLog.BlockNumber)
+		}
+		_, err := el.recordEvent(tx, vLog, ignoredEventName)
+		return err
+	}
+
+	fresh, err := el.recordEvent(tx, vLog, eventName)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		log.Printf("Event %s %s#%d already applied, skipping\n", eventName, vLog.TxHash.Hex(), vLog.Index)
+		return nil
+	}
+	return el.applyEvent(tx, eventName, vLog)
+}
+
+// applyEvent 根据事件名分发到对应的处理函数
+func (el *EventListener) applyEvent(tx *gorm.DB, eventName string, vLog types.Log) error {
 	switch eventName {
 	case "AuctionCreated":
-		el.handleAuctionCreated(vLog)
+		return el.handleAuctionCreated(tx, vLog)
 	case "BidPlaced":
-		el.handleBidPlaced(vLog)
+		return el.handleBidPlaced(tx, vLog)
 	case "AuctionEnded":
-		el.handleAuctionEnded(vLog)
-	default:
-		log.Printf("Unknown event: %s\n", vLog.Topics[0].Hex())
+		return el.handleAuctionEnded(tx, vLog)
+	case "Upgraded":
+		return el.handleUpgraded(tx, vLog)
 	}
+	return nil
 }
 
 // handleAuctionCreated 处理拍卖创建事件
-func (el *EventListener) handleAuctionCreated(vLog types.Log) {
-	type AuctionCreatedEvent struct {
-		AuctionId   *big.Int
-		Seller      common.Address
-		NftContract common.Address
-		TokenId     *big.Int
-		StartPrice  *big.Int
-		Duration    *big.Int
-		StartTime   *big.Int
-	}
-
-	var event AuctionCreatedEvent
-	err := el.contractABI.UnpackIntoInterface(&event, "AuctionCreated", vLog.Data)
+func (el *EventListener) handleAuctionCreated(db *gorm.DB, vLog types.Log) error {
+	event, err := el.filterer.ParseAuctionCreated(vLog)
 	if err != nil {
-		log.Printf("Failed to unpack AuctionCreated event: %v\n", err)
-		return
-	}
-
-	// 从 Topics 中提取 indexed 参数
-	if len(vLog.Topics) >= 4 {
-		event.AuctionId = new(big.Int).SetBytes(vLog.Topics[1].Bytes())
-		event.Seller = common.BytesToAddress(vLog.Topics[2].Bytes())
-		event.NftContract = common.BytesToAddress(vLog.Topics[3].Bytes())
+		return fmt.Errorf("%w: failed to unpack AuctionCreated event: %v", errDecode, err)
 	}
 
 	auction := models.Auction{
-		AuctionID:   uint(event.AuctionId.Uint64()),
-		Seller:      strings.ToLower(event.Seller.Hex()),
-		NFTContract: strings.ToLower(event.NftContract.Hex()),
-		TokenID:     event.TokenId.String(),
-		StartPrice:  event.StartPrice.String(),
-		Duration:    event.Duration.Uint64(),
-		StartTime:   event.StartTime.Uint64(),
-		Ended:       false,
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		AuctionID:       uint(event.AuctionId.Uint64()),
+		Seller:          strings.ToLower(event.Seller.Hex()),
+		NFTContract:     strings.ToLower(event.NftContract.Hex()),
+		TokenID:         event.TokenId.String(),
+		StartPrice:      event.StartPrice.String(),
+		Duration:        event.Duration.Uint64(),
+		StartTime:       event.StartTime.Uint64(),
+		Ended:           false,
+		CreatedBlock:    vLog.BlockNumber,
 	}
+	return saveAuction(db, &auction)
+}
 
-	db := database.GetDB()
-	if err := db.Create(&auction).Error; err != nil {
-		log.Printf("Failed to save auction: %v\n", err)
-		return
+// saveAuction 保存新创建的拍卖，拍卖已存在时以链上数据为准覆盖
+func saveAuction(db *gorm.DB, auction *models.Auction) error {
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "auction_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"seller", "nft_contract", "token_id", "start_price", "duration", "start_time", "created_block",
+		}),
+	}).Create(auction).Error; err != nil {
+		return fmt.Errorf("failed to save auction: %w", err)
 	}
 
 	log.Printf("Auction created: ID=%d, Seller=%s\n", auction.AuctionID, auction.Seller)
+	return nil
 }
 
 // handleBidPlaced 处理出价事件
-func (el *EventListener) handleBidPlaced(vLog types.Log) {
-	type BidPlacedEvent struct {
-		AuctionId    *big.Int
-		Bidder       common.Address
-		Amount       *big.Int
-		TokenAddress common.Address
-		Timestamp    *big.Int
-	}
-
-	var event BidPlacedEvent
-	err := el.contractABI.UnpackIntoInterface(&event, "BidPlaced", vLog.Data)
+func (el *EventListener) handleBidPlaced(db *gorm.DB, vLog types.Log) error {
+	event, err := el.filterer.ParseBidPlaced(vLog)
 	if err != nil {
-		log.Printf("Failed to unpack BidPlaced event: %v\n", err)
-		return
-	}
-
-	// 从 Topics 中提取 indexed 参数
-	if len(vLog.Topics) >= 3 {
-		event.AuctionId = new(big.Int).SetBytes(vLog.Topics[1].Bytes())
-		event.Bidder = common.BytesToAddress(vLog.Topics[2].Bytes())
+		return fmt.Errorf("%w: failed to unpack BidPlaced event: %v", errDecode, err)
 	}
 
 	bid := models.Bid{
-		AuctionID:    uint(event.AuctionId.Uint64()),
-		Bidder:       strings.ToLower(event.Bidder.Hex()),
-		Amount:       event.Amount.String(),
-		TokenAddress: strings.ToLower(event.TokenAddress.Hex()),
-		TxHash:       vLog.TxHash.Hex(),
-		BlockNumber:  vLog.BlockNumber,
-		Timestamp:    event.Timestamp.Uint64(),
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		AuctionID:       uint(event.AuctionId.Uint64()),
+		Bidder:          strings.ToLower(event.Bidder.Hex()),
+		Amount:          event.Amount.String(),
+		TokenAddress:    strings.ToLower(event.TokenAddress.Hex()),
+		TxHash:          vLog.TxHash.Hex(),
+		LogIndex:        vLog.Index,
+		BlockNumber:     vLog.BlockNumber,
+		Timestamp:       event.Timestamp.Uint64(),
 	}
+	return saveBid(db, &bid)
+}
 
-	db := database.GetDB()
-	// 保存出价记录
-	if err := db.Create(&bid).Error; err != nil {
-		log.Printf("Failed to save bid: %v\n", err)
-		return
+// saveBid 保存出价记录并更新拍卖的最高出价信息
+func saveBid(db *gorm.DB, bid *models.Bid) error {
+	// 保存出价记录，同一合约 (tx_hash, log_index) 的出价已存在时不再重复计数
+	result := db.Clauses(clause.OnConflict{Columns: identityColumns, DoNothing: true}).Create(bid)
+	if result.Error != nil {
+		return fmt.Errorf("failed to save bid: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
 	}
 
 	// 更新拍卖的最高出价信息
 	var auction models.Auction
-	if err := db.Where("auction_id = ?", bid.AuctionID).First(&auction).Error; err != nil {
-		log.Printf("Failed to find auction: %v\n", err)
-		return
+	if err := db.Scopes(chainContractScope(bid.ChainID, bid.ContractAddress)).Where("auction_id = ?", bid.AuctionID).
+		First(&auction).Error; err != nil {
+		return fmt.Errorf("failed to find auction: %w", err)
 	}
 
 	auction.HighestBidder = bid.Bidder
 	auction.HighestBid = bid.Amount
 	auction.TokenAddress = bid.TokenAddress
-	auction.BidCount++  // 增加出价次数
+	auction.BidCount++ // 增加出价次数
+	auction.Confirmed = false
 
 	if err := db.Save(&auction).Error; err != nil {
-		log.Printf("Failed to update auction: %v\n", err)
-		return
+		return fmt.Errorf("failed to update auction: %w", err)
 	}
 
 	log.Printf("Bid placed: AuctionID=%d, Bidder=%s, Amount=%s\n", bid.AuctionID, bid.Bidder, bid.Amount)
+	return nil
 }
 
 // handleAuctionEnded 处理拍卖结束事件
-func (el *EventListener) handleAuctionEnded(vLog types.Log) {
-	type AuctionEndedEvent struct {
-		AuctionId    *big.Int
-		Winner       common.Address
-		FinalPrice   *big.Int
-		TokenAddress common.Address
-		Timestamp    *big.Int
-	}
-
-	var event AuctionEndedEvent
-	err := el.contractABI.UnpackIntoInterface(&event, "AuctionEnded", vLog.Data)
+func (el *EventListener) handleAuctionEnded(db *gorm.DB, vLog types.Log) error {
+	event, err := el.filterer.ParseAuctionEnded(vLog)
 	if err != nil {
-		log.Printf("Failed to unpack AuctionEnded event: %v\n", err)
-		return
-	}
-
-	// 从 Topics 中提取 indexed 参数
-	if len(vLog.Topics) >= 3 {
-		event.AuctionId = new(big.Int).SetBytes(vLog.Topics[1].Bytes())
-		event.Winner = common.BytesToAddress(vLog.Topics[2].Bytes())
+		return fmt.Errorf("%w: failed to unpack AuctionEnded event: %v", errDecode, err)
 	}
 
-	db := database.GetDB()
 	var auction models.Auction
-	if err := db.Where("auction_id = ?", event.AuctionId.Uint64()).First(&auction).Error; err != nil {
-		log.Printf("Failed to find auction: %v\n", err)
-		return
+	if err := db.Scopes(el.scope()).Where("auction_id = ?", event.AuctionId.Uint64()).
+		First(&auction).Error; err != nil {
+		return fmt.Errorf("failed to find auction: %w", err)
 	}
 
-	endTime := event.Timestamp.Uint64()
-	auction.Ended = true
-	auction.EndTime = &endTime
 	auction.HighestBidder = strings.ToLower(event.Winner.Hex())
 	auction.HighestBid = event.FinalPrice.String()
 	auction.TokenAddress = strings.ToLower(event.TokenAddress.Hex())
+	return closeAuction(db, &auction, event.Timestamp.Uint64(), vLog.BlockNumber)
+}
 
-	if err := db.Save(&auction).Error; err != nil {
-		log.Printf("Failed to update auction: %v\n", err)
-		return
+// closeAuction 将拍卖标记为已结束
+func closeAuction(db *gorm.DB, auction *models.Auction, endTime, endedBlock uint64) error {
+	auction.Ended = true
+	auction.EndTime = &endTime
+	auction.EndedBlock = &endedBlock
+	auction.Confirmed = false
+
+	if err := db.Save(auction).Error; err != nil {
+		return fmt.Errorf("failed to update auction: %w", err)
 	}
 
-	log.Printf("Auction ended: ID=%d, Winner=%s, FinalPrice=%s\n", 
+	log.Printf("Auction ended: ID=%d, Winner=%s, FinalPrice=%s\n",
 		auction.AuctionID, auction.HighestBidder, auction.HighestBid)
+	return nil
 }
@@ -0,0 +1,1365 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// NftAuctionMetaData contains all meta data concerning the NftAuction contract.
+var NftAuctionMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"target\",\"type\":\"address\"}],\"name\":\"AddressEmptyCode\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"implementation\",\"type\":\"address\"}],\"name\":\"ERC1967InvalidImplementation\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ERC1967NonPayable\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"FailedCall\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidInitialization\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotInitializing\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"UUPSUnauthorizedCallContext\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"slot\",\"type\":\"bytes32\"}],\"name\":\"UUPSUnsupportedProxiableUUID\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"auctionId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"nftContract\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"startPrice\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"duration\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"}],\"name\":\"AuctionCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"auctionId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"winner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"finalPrice\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"tokenAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"AuctionEnded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"auctionId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"bidder\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"tokenAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"BidPlaced\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"}],\"name\":\"Initialized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"implementation\",\"type\":\"address\"}],\"name\":\"Upgraded\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"UPGRADE_INTERFACE_VERSION\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"admin\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"auctions\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"startPrice\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"duration\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isend\",\"type\":\"bool\"},{\"internalType\":\"address\",\"name\":\"highestBidder\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"hightestPrice\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"nftContract\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"tokenAddress\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_duration\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_startPrice\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_nftAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_tokenId\",\"type\":\"uint256\"}],\"name\":\"createAuction\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_auctionID\",\"type\":\"uint256\"}],\"name\":\"endAuction\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"tokenAddress\",\"type\":\"address\"}],\"name\":\"getChainlinkDataFeedLatestAnswer\",\"outputs\":[{\"internalType\":\"int256\",\"name\":\"\",\"type\":\"int256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nextAuctionId\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"onERC721Received\",\"outputs\":[{\"internalType\":\"bytes4\",\"name\":\"\",\"type\":\"bytes4\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_auctionID\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"_tokenAddress\",\"type\":\"address\"}],\"name\":\"placeBid\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"priceFeeds\",\"outputs\":[{\"internalType\":\"contractAggregatorV3Interface\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"proxiableUUID\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"tokenAddress\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_priceFeed\",\"type\":\"address\"}],\"name\":\"setPriceFeed\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newImplementation\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"upgradeToAndCall\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
+}
+
+// NftAuctionABI is the input ABI used to generate the binding from.
+// Deprecated: Use NftAuctionMetaData.ABI instead.
+var NftAuctionABI = NftAuctionMetaData.ABI
+
+// NftAuction is an auto generated Go binding around an Ethereum contract.
+type NftAuction struct {
+	NftAuctionCaller     // Read-only binding to the contract
+	NftAuctionTransactor // Write-only binding to the contract
+	NftAuctionFilterer   // Log filterer for contract events
+}
+
+// NftAuctionCaller is an auto generated read-only Go binding around an Ethereum contract.
+type NftAuctionCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NftAuctionTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type NftAuctionTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NftAuctionFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NftAuctionFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NftAuctionSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NftAuctionSession struct {
+	Contract     *NftAuction       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NftAuctionCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NftAuctionCallerSession struct {
+	Contract *NftAuctionCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// NftAuctionTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NftAuctionTransactorSession struct {
+	Contract     *NftAuctionTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// NftAuctionRaw is an auto generated low-level Go binding around an Ethereum contract.
+type NftAuctionRaw struct {
+	Contract *NftAuction // Generic contract binding to access the raw methods on
+}
+
+// NftAuctionCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NftAuctionCallerRaw struct {
+	Contract *NftAuctionCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// NftAuctionTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NftAuctionTransactorRaw struct {
+	Contract *NftAuctionTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNftAuction creates a new instance of NftAuction, bound to a specific deployed contract.
+func NewNftAuction(address common.Address, backend bind.ContractBackend) (*NftAuction, error) {
+	contract, err := bindNftAuction(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuction{NftAuctionCaller: NftAuctionCaller{contract: contract}, NftAuctionTransactor: NftAuctionTransactor{contract: contract}, NftAuctionFilterer: NftAuctionFilterer{contract: contract}}, nil
+}
+
+// NewNftAuctionCaller creates a new read-only instance of NftAuction, bound to a specific deployed contract.
+func NewNftAuctionCaller(address common.Address, caller bind.ContractCaller) (*NftAuctionCaller, error) {
+	contract, err := bindNftAuction(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionCaller{contract: contract}, nil
+}
+
+// NewNftAuctionTransactor creates a new write-only instance of NftAuction, bound to a specific deployed contract.
+func NewNftAuctionTransactor(address common.Address, transactor bind.ContractTransactor) (*NftAuctionTransactor, error) {
+	contract, err := bindNftAuction(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionTransactor{contract: contract}, nil
+}
+
+// NewNftAuctionFilterer creates a new log filterer instance of NftAuction, bound to a specific deployed contract.
+func NewNftAuctionFilterer(address common.Address, filterer bind.ContractFilterer) (*NftAuctionFilterer, error) {
+	contract, err := bindNftAuction(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionFilterer{contract: contract}, nil
+}
+
+// bindNftAuction binds a generic wrapper to an already deployed contract.
+func bindNftAuction(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := NftAuctionMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NftAuction *NftAuctionRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NftAuction.Contract.NftAuctionCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NftAuction *NftAuctionRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NftAuction.Contract.NftAuctionTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NftAuction *NftAuctionRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NftAuction.Contract.NftAuctionTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NftAuction *NftAuctionCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NftAuction.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NftAuction *NftAuctionTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NftAuction.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NftAuction *NftAuctionTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NftAuction.Contract.contract.Transact(opts, method, params...)
+}
+
+// UPGRADEINTERFACEVERSION is a free data retrieval call binding the contract method 0xad3cb1cc.
+//
+// Solidity: function UPGRADE_INTERFACE_VERSION() view returns(string)
+func (_NftAuction *NftAuctionCaller) UPGRADEINTERFACEVERSION(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "UPGRADE_INTERFACE_VERSION")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// UPGRADEINTERFACEVERSION is a free data retrieval call binding the contract method 0xad3cb1cc.
+//
+// Solidity: function UPGRADE_INTERFACE_VERSION() view returns(string)
+func (_NftAuction *NftAuctionSession) UPGRADEINTERFACEVERSION() (string, error) {
+	return _NftAuction.Contract.UPGRADEINTERFACEVERSION(&_NftAuction.CallOpts)
+}
+
+// UPGRADEINTERFACEVERSION is a free data retrieval call binding the contract method 0xad3cb1cc.
+//
+// Solidity: function UPGRADE_INTERFACE_VERSION() view returns(string)
+func (_NftAuction *NftAuctionCallerSession) UPGRADEINTERFACEVERSION() (string, error) {
+	return _NftAuction.Contract.UPGRADEINTERFACEVERSION(&_NftAuction.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_NftAuction *NftAuctionCaller) Admin(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "admin")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_NftAuction *NftAuctionSession) Admin() (common.Address, error) {
+	return _NftAuction.Contract.Admin(&_NftAuction.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_NftAuction *NftAuctionCallerSession) Admin() (common.Address, error) {
+	return _NftAuction.Contract.Admin(&_NftAuction.CallOpts)
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 ) view returns(address seller, uint256 startPrice, uint256 startTime, uint256 duration, bool isend, address highestBidder, uint256 hightestPrice, address nftContract, uint256 tokenId, address tokenAddress)
+func (_NftAuction *NftAuctionCaller) Auctions(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Seller        common.Address
+	StartPrice    *big.Int
+	StartTime     *big.Int
+	Duration      *big.Int
+	Isend         bool
+	HighestBidder common.Address
+	HightestPrice *big.Int
+	NftContract   common.Address
+	TokenId       *big.Int
+	TokenAddress  common.Address
+}, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "auctions", arg0)
+
+	outstruct := new(struct {
+		Seller        common.Address
+		StartPrice    *big.Int
+		StartTime     *big.Int
+		Duration      *big.Int
+		Isend         bool
+		HighestBidder common.Address
+		HightestPrice *big.Int
+		NftContract   common.Address
+		TokenId       *big.Int
+		TokenAddress  common.Address
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Seller = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.StartPrice = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.StartTime = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.Duration = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Isend = *abi.ConvertType(out[4], new(bool)).(*bool)
+	outstruct.HighestBidder = *abi.ConvertType(out[5], new(common.Address)).(*common.Address)
+	outstruct.HightestPrice = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.NftContract = *abi.ConvertType(out[7], new(common.Address)).(*common.Address)
+	outstruct.TokenId = *abi.ConvertType(out[8], new(*big.Int)).(**big.Int)
+	outstruct.TokenAddress = *abi.ConvertType(out[9], new(common.Address)).(*common.Address)
+
+	return *outstruct, err
+
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 ) view returns(address seller, uint256 startPrice, uint256 startTime, uint256 duration, bool isend, address highestBidder, uint256 hightestPrice, address nftContract, uint256 tokenId, address tokenAddress)
+func (_NftAuction *NftAuctionSession) Auctions(arg0 *big.Int) (struct {
+	Seller        common.Address
+	StartPrice    *big.Int
+	StartTime     *big.Int
+	Duration      *big.Int
+	Isend         bool
+	HighestBidder common.Address
+	HightestPrice *big.Int
+	NftContract   common.Address
+	TokenId       *big.Int
+	TokenAddress  common.Address
+}, error) {
+	return _NftAuction.Contract.Auctions(&_NftAuction.CallOpts, arg0)
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 ) view returns(address seller, uint256 startPrice, uint256 startTime, uint256 duration, bool isend, address highestBidder, uint256 hightestPrice, address nftContract, uint256 tokenId, address tokenAddress)
+func (_NftAuction *NftAuctionCallerSession) Auctions(arg0 *big.Int) (struct {
+	Seller        common.Address
+	StartPrice    *big.Int
+	StartTime     *big.Int
+	Duration      *big.Int
+	Isend         bool
+	HighestBidder common.Address
+	HightestPrice *big.Int
+	NftContract   common.Address
+	TokenId       *big.Int
+	TokenAddress  common.Address
+}, error) {
+	return _NftAuction.Contract.Auctions(&_NftAuction.CallOpts, arg0)
+}
+
+// GetChainlinkDataFeedLatestAnswer is a free data retrieval call binding the contract method 0xe7078f92.
+//
+// Solidity: function getChainlinkDataFeedLatestAnswer(address tokenAddress) view returns(int256)
+func (_NftAuction *NftAuctionCaller) GetChainlinkDataFeedLatestAnswer(opts *bind.CallOpts, tokenAddress common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "getChainlinkDataFeedLatestAnswer", tokenAddress)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetChainlinkDataFeedLatestAnswer is a free data retrieval call binding the contract method 0xe7078f92.
+//
+// Solidity: function getChainlinkDataFeedLatestAnswer(address tokenAddress) view returns(int256)
+func (_NftAuction *NftAuctionSession) GetChainlinkDataFeedLatestAnswer(tokenAddress common.Address) (*big.Int, error) {
+	return _NftAuction.Contract.GetChainlinkDataFeedLatestAnswer(&_NftAuction.CallOpts, tokenAddress)
+}
+
+// GetChainlinkDataFeedLatestAnswer is a free data retrieval call binding the contract method 0xe7078f92.
+//
+// Solidity: function getChainlinkDataFeedLatestAnswer(address tokenAddress) view returns(int256)
+func (_NftAuction *NftAuctionCallerSession) GetChainlinkDataFeedLatestAnswer(tokenAddress common.Address) (*big.Int, error) {
+	return _NftAuction.Contract.GetChainlinkDataFeedLatestAnswer(&_NftAuction.CallOpts, tokenAddress)
+}
+
+// NextAuctionId is a free data retrieval call binding the contract method 0xfc528482.
+//
+// Solidity: function nextAuctionId() view returns(uint256)
+func (_NftAuction *NftAuctionCaller) NextAuctionId(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "nextAuctionId")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextAuctionId is a free data retrieval call binding the contract method 0xfc528482.
+//
+// Solidity: function nextAuctionId() view returns(uint256)
+func (_NftAuction *NftAuctionSession) NextAuctionId() (*big.Int, error) {
+	return _NftAuction.Contract.NextAuctionId(&_NftAuction.CallOpts)
+}
+
+// NextAuctionId is a free data retrieval call binding the contract method 0xfc528482.
+//
+// Solidity: function nextAuctionId() view returns(uint256)
+func (_NftAuction *NftAuctionCallerSession) NextAuctionId() (*big.Int, error) {
+	return _NftAuction.Contract.NextAuctionId(&_NftAuction.CallOpts)
+}
+
+// OnERC721Received is a free data retrieval call binding the contract method 0x150b7a02.
+//
+// Solidity: function onERC721Received(address operator, address from, uint256 tokenId, bytes data) pure returns(bytes4)
+func (_NftAuction *NftAuctionCaller) OnERC721Received(opts *bind.CallOpts, operator common.Address, from common.Address, tokenId *big.Int, data []byte) ([4]byte, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "onERC721Received", operator, from, tokenId, data)
+
+	if err != nil {
+		return *new([4]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([4]byte)).(*[4]byte)
+
+	return out0, err
+
+}
+
+// OnERC721Received is a free data retrieval call binding the contract method 0x150b7a02.
+//
+// Solidity: function onERC721Received(address operator, address from, uint256 tokenId, bytes data) pure returns(bytes4)
+func (_NftAuction *NftAuctionSession) OnERC721Received(operator common.Address, from common.Address, tokenId *big.Int, data []byte) ([4]byte, error) {
+	return _NftAuction.Contract.OnERC721Received(&_NftAuction.CallOpts, operator, from, tokenId, data)
+}
+
+// OnERC721Received is a free data retrieval call binding the contract method 0x150b7a02.
+//
+// Solidity: function onERC721Received(address operator, address from, uint256 tokenId, bytes data) pure returns(bytes4)
+func (_NftAuction *NftAuctionCallerSession) OnERC721Received(operator common.Address, from common.Address, tokenId *big.Int, data []byte) ([4]byte, error) {
+	return _NftAuction.Contract.OnERC721Received(&_NftAuction.CallOpts, operator, from, tokenId, data)
+}
+
+// PriceFeeds is a free data retrieval call binding the contract method 0x9dcb511a.
+//
+// Solidity: function priceFeeds(address ) view returns(address)
+func (_NftAuction *NftAuctionCaller) PriceFeeds(opts *bind.CallOpts, arg0 common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "priceFeeds", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PriceFeeds is a free data retrieval call binding the contract method 0x9dcb511a.
+//
+// Solidity: function priceFeeds(address ) view returns(address)
+func (_NftAuction *NftAuctionSession) PriceFeeds(arg0 common.Address) (common.Address, error) {
+	return _NftAuction.Contract.PriceFeeds(&_NftAuction.CallOpts, arg0)
+}
+
+// PriceFeeds is a free data retrieval call binding the contract method 0x9dcb511a.
+//
+// Solidity: function priceFeeds(address ) view returns(address)
+func (_NftAuction *NftAuctionCallerSession) PriceFeeds(arg0 common.Address) (common.Address, error) {
+	return _NftAuction.Contract.PriceFeeds(&_NftAuction.CallOpts, arg0)
+}
+
+// ProxiableUUID is a free data retrieval call binding the contract method 0x52d1902d.
+//
+// Solidity: function proxiableUUID() view returns(bytes32)
+func (_NftAuction *NftAuctionCaller) ProxiableUUID(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _NftAuction.contract.Call(opts, &out, "proxiableUUID")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// ProxiableUUID is a free data retrieval call binding the contract method 0x52d1902d.
+//
+// Solidity: function proxiableUUID() view returns(bytes32)
+func (_NftAuction *NftAuctionSession) ProxiableUUID() ([32]byte, error) {
+	return _NftAuction.Contract.ProxiableUUID(&_NftAuction.CallOpts)
+}
+
+// ProxiableUUID is a free data retrieval call binding the contract method 0x52d1902d.
+//
+// Solidity: function proxiableUUID() view returns(bytes32)
+func (_NftAuction *NftAuctionCallerSession) ProxiableUUID() ([32]byte, error) {
+	return _NftAuction.Contract.ProxiableUUID(&_NftAuction.CallOpts)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xb1cb48ef.
+//
+// Solidity: function createAuction(uint256 _duration, uint256 _startPrice, address _nftAddress, uint256 _tokenId) returns()
+func (_NftAuction *NftAuctionTransactor) CreateAuction(opts *bind.TransactOpts, _duration *big.Int, _startPrice *big.Int, _nftAddress common.Address, _tokenId *big.Int) (*types.Transaction, error) {
+	return _NftAuction.contract.Transact(opts, "createAuction", _duration, _startPrice, _nftAddress, _tokenId)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xb1cb48ef.
+//
+// Solidity: function createAuction(uint256 _duration, uint256 _startPrice, address _nftAddress, uint256 _tokenId) returns()
+func (_NftAuction *NftAuctionSession) CreateAuction(_duration *big.Int, _startPrice *big.Int, _nftAddress common.Address, _tokenId *big.Int) (*types.Transaction, error) {
+	return _NftAuction.Contract.CreateAuction(&_NftAuction.TransactOpts, _duration, _startPrice, _nftAddress, _tokenId)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xb1cb48ef.
+//
+// Solidity: function createAuction(uint256 _duration, uint256 _startPrice, address _nftAddress, uint256 _tokenId) returns()
+func (_NftAuction *NftAuctionTransactorSession) CreateAuction(_duration *big.Int, _startPrice *big.Int, _nftAddress common.Address, _tokenId *big.Int) (*types.Transaction, error) {
+	return _NftAuction.Contract.CreateAuction(&_NftAuction.TransactOpts, _duration, _startPrice, _nftAddress, _tokenId)
+}
+
+// EndAuction is a paid mutator transaction binding the contract method 0xb9a2de3a.
+//
+// Solidity: function endAuction(uint256 _auctionID) returns()
+func (_NftAuction *NftAuctionTransactor) EndAuction(opts *bind.TransactOpts, _auctionID *big.Int) (*types.Transaction, error) {
+	return _NftAuction.contract.Transact(opts, "endAuction", _auctionID)
+}
+
+// EndAuction is a paid mutator transaction binding the contract method 0xb9a2de3a.
+//
+// Solidity: function endAuction(uint256 _auctionID) returns()
+func (_NftAuction *NftAuctionSession) EndAuction(_auctionID *big.Int) (*types.Transaction, error) {
+	return _NftAuction.Contract.EndAuction(&_NftAuction.TransactOpts, _auctionID)
+}
+
+// EndAuction is a paid mutator transaction binding the contract method 0xb9a2de3a.
+//
+// Solidity: function endAuction(uint256 _auctionID) returns()
+func (_NftAuction *NftAuctionTransactorSession) EndAuction(_auctionID *big.Int) (*types.Transaction, error) {
+	return _NftAuction.Contract.EndAuction(&_NftAuction.TransactOpts, _auctionID)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_NftAuction *NftAuctionTransactor) Initialize(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NftAuction.contract.Transact(opts, "initialize")
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_NftAuction *NftAuctionSession) Initialize() (*types.Transaction, error) {
+	return _NftAuction.Contract.Initialize(&_NftAuction.TransactOpts)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_NftAuction *NftAuctionTransactorSession) Initialize() (*types.Transaction, error) {
+	return _NftAuction.Contract.Initialize(&_NftAuction.TransactOpts)
+}
+
+// PlaceBid is a paid mutator transaction binding the contract method 0xad6561ec.
+//
+// Solidity: function placeBid(uint256 _auctionID, uint256 amount, address _tokenAddress) payable returns()
+func (_NftAuction *NftAuctionTransactor) PlaceBid(opts *bind.TransactOpts, _auctionID *big.Int, amount *big.Int, _tokenAddress common.Address) (*types.Transaction, error) {
+	return _NftAuction.contract.Transact(opts, "placeBid", _auctionID, amount, _tokenAddress)
+}
+
+// PlaceBid is a paid mutator transaction binding the contract method 0xad6561ec.
+//
+// Solidity: function placeBid(uint256 _auctionID, uint256 amount, address _tokenAddress) payable returns()
+func (_NftAuction *NftAuctionSession) PlaceBid(_auctionID *big.Int, amount *big.Int, _tokenAddress common.Address) (*types.Transaction, error) {
+	return _NftAuction.Contract.PlaceBid(&_NftAuction.TransactOpts, _auctionID, amount, _tokenAddress)
+}
+
+// PlaceBid is a paid mutator transaction binding the contract method 0xad6561ec.
+//
+// Solidity: function placeBid(uint256 _auctionID, uint256 amount, address _tokenAddress) payable returns()
+func (_NftAuction *NftAuctionTransactorSession) PlaceBid(_auctionID *big.Int, amount *big.Int, _tokenAddress common.Address) (*types.Transaction, error) {
+	return _NftAuction.Contract.PlaceBid(&_NftAuction.TransactOpts, _auctionID, amount, _tokenAddress)
+}
+
+// SetPriceFeed is a paid mutator transaction binding the contract method 0x76e11286.
+//
+// Solidity: function setPriceFeed(address tokenAddress, address _priceFeed) returns()
+func (_NftAuction *NftAuctionTransactor) SetPriceFeed(opts *bind.TransactOpts, tokenAddress common.Address, _priceFeed common.Address) (*types.Transaction, error) {
+	return _NftAuction.contract.Transact(opts, "setPriceFeed", tokenAddress, _priceFeed)
+}
+
+// SetPriceFeed is a paid mutator transaction binding the contract method 0x76e11286.
+//
+// Solidity: function setPriceFeed(address tokenAddress, address _priceFeed) returns()
+func (_NftAuction *NftAuctionSession) SetPriceFeed(tokenAddress common.Address, _priceFeed common.Address) (*types.Transaction, error) {
+	return _NftAuction.Contract.SetPriceFeed(&_NftAuction.TransactOpts, tokenAddress, _priceFeed)
+}
+
+// SetPriceFeed is a paid mutator transaction binding the contract method 0x76e11286.
+//
+// Solidity: function setPriceFeed(address tokenAddress, address _priceFeed) returns()
+func (_NftAuction *NftAuctionTransactorSession) SetPriceFeed(tokenAddress common.Address, _priceFeed common.Address) (*types.Transaction, error) {
+	return _NftAuction.Contract.SetPriceFeed(&_NftAuction.TransactOpts, tokenAddress, _priceFeed)
+}
+
+// UpgradeToAndCall is a paid mutator transaction binding the contract method 0x4f1ef286.
+//
+// Solidity: function upgradeToAndCall(address newImplementation, bytes data) payable returns()
+func (_NftAuction *NftAuctionTransactor) UpgradeToAndCall(opts *bind.TransactOpts, newImplementation common.Address, data []byte) (*types.Transaction, error) {
+	return _NftAuction.contract.Transact(opts, "upgradeToAndCall", newImplementation, data)
+}
+
+// UpgradeToAndCall is a paid mutator transaction binding the contract method 0x4f1ef286.
+//
+// Solidity: function upgradeToAndCall(address newImplementation, bytes data) payable returns()
+func (_NftAuction *NftAuctionSession) UpgradeToAndCall(newImplementation common.Address, data []byte) (*types.Transaction, error) {
+	return _NftAuction.Contract.UpgradeToAndCall(&_NftAuction.TransactOpts, newImplementation, data)
+}
+
+// UpgradeToAndCall is a paid mutator transaction binding the contract method 0x4f1ef286.
+//
+// Solidity: function upgradeToAndCall(address newImplementation, bytes data) payable returns()
+func (_NftAuction *NftAuctionTransactorSession) UpgradeToAndCall(newImplementation common.Address, data []byte) (*types.Transaction, error) {
+	return _NftAuction.Contract.UpgradeToAndCall(&_NftAuction.TransactOpts, newImplementation, data)
+}
+
+// NftAuctionAuctionCreatedIterator is returned from FilterAuctionCreated and is used to iterate over the raw logs and unpacked data for AuctionCreated events raised by the NftAuction contract.
+type NftAuctionAuctionCreatedIterator struct {
+	Event *NftAuctionAuctionCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NftAuctionAuctionCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NftAuctionAuctionCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NftAuctionAuctionCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NftAuctionAuctionCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NftAuctionAuctionCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NftAuctionAuctionCreated represents a AuctionCreated event raised by the NftAuction contract.
+type NftAuctionAuctionCreated struct {
+	AuctionId   *big.Int
+	Seller      common.Address
+	NftContract common.Address
+	TokenId     *big.Int
+	StartPrice  *big.Int
+	Duration    *big.Int
+	StartTime   *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuctionCreated is a free log retrieval operation binding the contract event 0xcaf0ae751fb2b122e8718bf7c0d4b7584d1418a853a4d0cdaba45418d3da138b.
+//
+// Solidity: event AuctionCreated(uint256 indexed auctionId, address indexed seller, address indexed nftContract, uint256 tokenId, uint256 startPrice, uint256 duration, uint256 startTime)
+func (_NftAuction *NftAuctionFilterer) FilterAuctionCreated(opts *bind.FilterOpts, auctionId []*big.Int, seller []common.Address, nftContract []common.Address) (*NftAuctionAuctionCreatedIterator, error) {
+
+	var auctionIdRule []interface{}
+	for _, auctionIdItem := range auctionId {
+		auctionIdRule = append(auctionIdRule, auctionIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+	var nftContractRule []interface{}
+	for _, nftContractItem := range nftContract {
+		nftContractRule = append(nftContractRule, nftContractItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.FilterLogs(opts, "AuctionCreated", auctionIdRule, sellerRule, nftContractRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionAuctionCreatedIterator{contract: _NftAuction.contract, event: "AuctionCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchAuctionCreated is a free log subscription operation binding the contract event 0xcaf0ae751fb2b122e8718bf7c0d4b7584d1418a853a4d0cdaba45418d3da138b.
+//
+// Solidity: event AuctionCreated(uint256 indexed auctionId, address indexed seller, address indexed nftContract, uint256 tokenId, uint256 startPrice, uint256 duration, uint256 startTime)
+func (_NftAuction *NftAuctionFilterer) WatchAuctionCreated(opts *bind.WatchOpts, sink chan<- *NftAuctionAuctionCreated, auctionId []*big.Int, seller []common.Address, nftContract []common.Address) (event.Subscription, error) {
+
+	var auctionIdRule []interface{}
+	for _, auctionIdItem := range auctionId {
+		auctionIdRule = append(auctionIdRule, auctionIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+	var nftContractRule []interface{}
+	for _, nftContractItem := range nftContract {
+		nftContractRule = append(nftContractRule, nftContractItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.WatchLogs(opts, "AuctionCreated", auctionIdRule, sellerRule, nftContractRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NftAuctionAuctionCreated)
+				if err := _NftAuction.contract.UnpackLog(event, "AuctionCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuctionCreated is a log parse operation binding the contract event 0xcaf0ae751fb2b122e8718bf7c0d4b7584d1418a853a4d0cdaba45418d3da138b.
+//
+// Solidity: event AuctionCreated(uint256 indexed auctionId, address indexed seller, address indexed nftContract, uint256 tokenId, uint256 startPrice, uint256 duration, uint256 startTime)
+func (_NftAuction *NftAuctionFilterer) ParseAuctionCreated(log types.Log) (*NftAuctionAuctionCreated, error) {
+	event := new(NftAuctionAuctionCreated)
+	if err := _NftAuction.contract.UnpackLog(event, "AuctionCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NftAuctionAuctionEndedIterator is returned from FilterAuctionEnded and is used to iterate over the raw logs and unpacked data for AuctionEnded events raised by the NftAuction contract.
+type NftAuctionAuctionEndedIterator struct {
+	Event *NftAuctionAuctionEnded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NftAuctionAuctionEndedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NftAuctionAuctionEnded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NftAuctionAuctionEnded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NftAuctionAuctionEndedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NftAuctionAuctionEndedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NftAuctionAuctionEnded represents a AuctionEnded event raised by the NftAuction contract.
+type NftAuctionAuctionEnded struct {
+	AuctionId    *big.Int
+	Winner       common.Address
+	FinalPrice   *big.Int
+	TokenAddress common.Address
+	Timestamp    *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuctionEnded is a free log retrieval operation binding the contract event 0xe5475ecf90fac88802f904d6cb8390f7106dec2526f70b078ce579d6f7221251.
+//
+// Solidity: event AuctionEnded(uint256 indexed auctionId, address indexed winner, uint256 finalPrice, address tokenAddress, uint256 timestamp)
+func (_NftAuction *NftAuctionFilterer) FilterAuctionEnded(opts *bind.FilterOpts, auctionId []*big.Int, winner []common.Address) (*NftAuctionAuctionEndedIterator, error) {
+
+	var auctionIdRule []interface{}
+	for _, auctionIdItem := range auctionId {
+		auctionIdRule = append(auctionIdRule, auctionIdItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.FilterLogs(opts, "AuctionEnded", auctionIdRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionAuctionEndedIterator{contract: _NftAuction.contract, event: "AuctionEnded", logs: logs, sub: sub}, nil
+}
+
+// WatchAuctionEnded is a free log subscription operation binding the contract event 0xe5475ecf90fac88802f904d6cb8390f7106dec2526f70b078ce579d6f7221251.
+//
+// Solidity: event AuctionEnded(uint256 indexed auctionId, address indexed winner, uint256 finalPrice, address tokenAddress, uint256 timestamp)
+func (_NftAuction *NftAuctionFilterer) WatchAuctionEnded(opts *bind.WatchOpts, sink chan<- *NftAuctionAuctionEnded, auctionId []*big.Int, winner []common.Address) (event.Subscription, error) {
+
+	var auctionIdRule []interface{}
+	for _, auctionIdItem := range auctionId {
+		auctionIdRule = append(auctionIdRule, auctionIdItem)
+	}
+	var winnerRule []interface{}
+	for _, winnerItem := range winner {
+		winnerRule = append(winnerRule, winnerItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.WatchLogs(opts, "AuctionEnded", auctionIdRule, winnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NftAuctionAuctionEnded)
+				if err := _NftAuction.contract.UnpackLog(event, "AuctionEnded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuctionEnded is a log parse operation binding the contract event 0xe5475ecf90fac88802f904d6cb8390f7106dec2526f70b078ce579d6f7221251.
+//
+// Solidity: event AuctionEnded(uint256 indexed auctionId, address indexed winner, uint256 finalPrice, address tokenAddress, uint256 timestamp)
+func (_NftAuction *NftAuctionFilterer) ParseAuctionEnded(log types.Log) (*NftAuctionAuctionEnded, error) {
+	event := new(NftAuctionAuctionEnded)
+	if err := _NftAuction.contract.UnpackLog(event, "AuctionEnded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NftAuctionBidPlacedIterator is returned from FilterBidPlaced and is used to iterate over the raw logs and unpacked data for BidPlaced events raised by the NftAuction contract.
+type NftAuctionBidPlacedIterator struct {
+	Event *NftAuctionBidPlaced // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NftAuctionBidPlacedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NftAuctionBidPlaced)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NftAuctionBidPlaced)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NftAuctionBidPlacedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NftAuctionBidPlacedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NftAuctionBidPlaced represents a BidPlaced event raised by the NftAuction contract.
+type NftAuctionBidPlaced struct {
+	AuctionId    *big.Int
+	Bidder       common.Address
+	Amount       *big.Int
+	TokenAddress common.Address
+	Timestamp    *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterBidPlaced is a free log retrieval operation binding the contract event 0x2e296671c28b83e813c76e2acf7481f5a2cc46aaeb9bcf33b3e048f50e9c33e9.
+//
+// Solidity: event BidPlaced(uint256 indexed auctionId, address indexed bidder, uint256 amount, address tokenAddress, uint256 timestamp)
+func (_NftAuction *NftAuctionFilterer) FilterBidPlaced(opts *bind.FilterOpts, auctionId []*big.Int, bidder []common.Address) (*NftAuctionBidPlacedIterator, error) {
+
+	var auctionIdRule []interface{}
+	for _, auctionIdItem := range auctionId {
+		auctionIdRule = append(auctionIdRule, auctionIdItem)
+	}
+	var bidderRule []interface{}
+	for _, bidderItem := range bidder {
+		bidderRule = append(bidderRule, bidderItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.FilterLogs(opts, "BidPlaced", auctionIdRule, bidderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionBidPlacedIterator{contract: _NftAuction.contract, event: "BidPlaced", logs: logs, sub: sub}, nil
+}
+
+// WatchBidPlaced is a free log subscription operation binding the contract event 0x2e296671c28b83e813c76e2acf7481f5a2cc46aaeb9bcf33b3e048f50e9c33e9.
+//
+// Solidity: event BidPlaced(uint256 indexed auctionId, address indexed bidder, uint256 amount, address tokenAddress, uint256 timestamp)
+func (_NftAuction *NftAuctionFilterer) WatchBidPlaced(opts *bind.WatchOpts, sink chan<- *NftAuctionBidPlaced, auctionId []*big.Int, bidder []common.Address) (event.Subscription, error) {
+
+	var auctionIdRule []interface{}
+	for _, auctionIdItem := range auctionId {
+		auctionIdRule = append(auctionIdRule, auctionIdItem)
+	}
+	var bidderRule []interface{}
+	for _, bidderItem := range bidder {
+		bidderRule = append(bidderRule, bidderItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.WatchLogs(opts, "BidPlaced", auctionIdRule, bidderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NftAuctionBidPlaced)
+				if err := _NftAuction.contract.UnpackLog(event, "BidPlaced", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBidPlaced is a log parse operation binding the contract event 0x2e296671c28b83e813c76e2acf7481f5a2cc46aaeb9bcf33b3e048f50e9c33e9.
+//
+// Solidity: event BidPlaced(uint256 indexed auctionId, address indexed bidder, uint256 amount, address tokenAddress, uint256 timestamp)
+func (_NftAuction *NftAuctionFilterer) ParseBidPlaced(log types.Log) (*NftAuctionBidPlaced, error) {
+	event := new(NftAuctionBidPlaced)
+	if err := _NftAuction.contract.UnpackLog(event, "BidPlaced", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NftAuctionInitializedIterator is returned from FilterInitialized and is used to iterate over the raw logs and unpacked data for Initialized events raised by the NftAuction contract.
+type NftAuctionInitializedIterator struct {
+	Event *NftAuctionInitialized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NftAuctionInitializedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NftAuctionInitialized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NftAuctionInitialized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NftAuctionInitializedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NftAuctionInitializedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NftAuctionInitialized represents a Initialized event raised by the NftAuction contract.
+type NftAuctionInitialized struct {
+	Version uint64
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterInitialized is a free log retrieval operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_NftAuction *NftAuctionFilterer) FilterInitialized(opts *bind.FilterOpts) (*NftAuctionInitializedIterator, error) {
+
+	logs, sub, err := _NftAuction.contract.FilterLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionInitializedIterator{contract: _NftAuction.contract, event: "Initialized", logs: logs, sub: sub}, nil
+}
+
+// WatchInitialized is a free log subscription operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_NftAuction *NftAuctionFilterer) WatchInitialized(opts *bind.WatchOpts, sink chan<- *NftAuctionInitialized) (event.Subscription, error) {
+
+	logs, sub, err := _NftAuction.contract.WatchLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NftAuctionInitialized)
+				if err := _NftAuction.contract.UnpackLog(event, "Initialized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseInitialized is a log parse operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_NftAuction *NftAuctionFilterer) ParseInitialized(log types.Log) (*NftAuctionInitialized, error) {
+	event := new(NftAuctionInitialized)
+	if err := _NftAuction.contract.UnpackLog(event, "Initialized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NftAuctionUpgradedIterator is returned from FilterUpgraded and is used to iterate over the raw logs and unpacked data for Upgraded events raised by the NftAuction contract.
+type NftAuctionUpgradedIterator struct {
+	Event *NftAuctionUpgraded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NftAuctionUpgradedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NftAuctionUpgraded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NftAuctionUpgraded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NftAuctionUpgradedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NftAuctionUpgradedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NftAuctionUpgraded represents a Upgraded event raised by the NftAuction contract.
+type NftAuctionUpgraded struct {
+	Implementation common.Address
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterUpgraded is a free log retrieval operation binding the contract event 0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b.
+//
+// Solidity: event Upgraded(address indexed implementation)
+func (_NftAuction *NftAuctionFilterer) FilterUpgraded(opts *bind.FilterOpts, implementation []common.Address) (*NftAuctionUpgradedIterator, error) {
+
+	var implementationRule []interface{}
+	for _, implementationItem := range implementation {
+		implementationRule = append(implementationRule, implementationItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.FilterLogs(opts, "Upgraded", implementationRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionUpgradedIterator{contract: _NftAuction.contract, event: "Upgraded", logs: logs, sub: sub}, nil
+}
+
+// WatchUpgraded is a free log subscription operation binding the contract event 0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b.
+//
+// Solidity: event Upgraded(address indexed implementation)
+func (_NftAuction *NftAuctionFilterer) WatchUpgraded(opts *bind.WatchOpts, sink chan<- *NftAuctionUpgraded, implementation []common.Address) (event.Subscription, error) {
+
+	var implementationRule []interface{}
+	for _, implementationItem := range implementation {
+		implementationRule = append(implementationRule, implementationItem)
+	}
+
+	logs, sub, err := _NftAuction.contract.WatchLogs(opts, "Upgraded", implementationRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NftAuctionUpgraded)
+				if err := _NftAuction.contract.UnpackLog(event, "Upgraded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUpgraded is a log parse operation binding the contract event 0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b.
+//
+// Solidity: event Upgraded(address indexed implementation)
+func (_NftAuction *NftAuctionFilterer) ParseUpgraded(log types.Log) (*NftAuctionUpgraded, error) {
+	event := new(NftAuctionUpgraded)
+	if err := _NftAuction.contract.UnpackLog(event, "Upgraded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,546 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// NftAuctionV2MetaData contains all meta data concerning the NftAuctionV2 contract.
+var NftAuctionV2MetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"error\",\"name\":\"InvalidInitialization\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NotInitializing\",\"inputs\":[]},{\"type\":\"event\",\"name\":\"Initialized\",\"anonymous\":false,\"inputs\":[{\"name\":\"version\",\"type\":\"uint64\",\"indexed\":false,\"internalType\":\"uint64\"}]},{\"type\":\"function\",\"name\":\"admin\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"function\",\"name\":\"auctions\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"seller\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"startPrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"startTime\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"duration\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"isend\",\"type\":\"bool\",\"internalType\":\"bool\"},{\"name\":\"highestBidder\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"hightestPrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"nftContract\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"tokenId\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"function\",\"name\":\"createAuction\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_duration\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_startPrice\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_nftAddress\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_tokenId\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"endAuction\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"_auctionID\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"initialize\",\"stateMutability\":\"nonpayable\",\"inputs\":[],\"outputs\":[]},{\"type\":\"function\",\"name\":\"nextAuctionId\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"placeBid\",\"stateMutability\":\"payable\",\"inputs\":[{\"name\":\"_auctionID\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_tokenAddress\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[]}]",
+}
+
+// NftAuctionV2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use NftAuctionV2MetaData.ABI instead.
+var NftAuctionV2ABI = NftAuctionV2MetaData.ABI
+
+// NftAuctionV2 is an auto generated Go binding around an Ethereum contract.
+type NftAuctionV2 struct {
+	NftAuctionV2Caller     // Read-only binding to the contract
+	NftAuctionV2Transactor // Write-only binding to the contract
+	NftAuctionV2Filterer   // Log filterer for contract events
+}
+
+// NftAuctionV2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type NftAuctionV2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NftAuctionV2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type NftAuctionV2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NftAuctionV2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type NftAuctionV2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// NftAuctionV2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type NftAuctionV2Session struct {
+	Contract     *NftAuctionV2     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// NftAuctionV2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type NftAuctionV2CallerSession struct {
+	Contract *NftAuctionV2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// NftAuctionV2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type NftAuctionV2TransactorSession struct {
+	Contract     *NftAuctionV2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// NftAuctionV2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type NftAuctionV2Raw struct {
+	Contract *NftAuctionV2 // Generic contract binding to access the raw methods on
+}
+
+// NftAuctionV2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type NftAuctionV2CallerRaw struct {
+	Contract *NftAuctionV2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// NftAuctionV2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type NftAuctionV2TransactorRaw struct {
+	Contract *NftAuctionV2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewNftAuctionV2 creates a new instance of NftAuctionV2, bound to a specific deployed contract.
+func NewNftAuctionV2(address common.Address, backend bind.ContractBackend) (*NftAuctionV2, error) {
+	contract, err := bindNftAuctionV2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionV2{NftAuctionV2Caller: NftAuctionV2Caller{contract: contract}, NftAuctionV2Transactor: NftAuctionV2Transactor{contract: contract}, NftAuctionV2Filterer: NftAuctionV2Filterer{contract: contract}}, nil
+}
+
+// NewNftAuctionV2Caller creates a new read-only instance of NftAuctionV2, bound to a specific deployed contract.
+func NewNftAuctionV2Caller(address common.Address, caller bind.ContractCaller) (*NftAuctionV2Caller, error) {
+	contract, err := bindNftAuctionV2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionV2Caller{contract: contract}, nil
+}
+
+// NewNftAuctionV2Transactor creates a new write-only instance of NftAuctionV2, bound to a specific deployed contract.
+func NewNftAuctionV2Transactor(address common.Address, transactor bind.ContractTransactor) (*NftAuctionV2Transactor, error) {
+	contract, err := bindNftAuctionV2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionV2Transactor{contract: contract}, nil
+}
+
+// NewNftAuctionV2Filterer creates a new log filterer instance of NftAuctionV2, bound to a specific deployed contract.
+func NewNftAuctionV2Filterer(address common.Address, filterer bind.ContractFilterer) (*NftAuctionV2Filterer, error) {
+	contract, err := bindNftAuctionV2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionV2Filterer{contract: contract}, nil
+}
+
+// bindNftAuctionV2 binds a generic wrapper to an already deployed contract.
+func bindNftAuctionV2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := NftAuctionV2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NftAuctionV2 *NftAuctionV2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NftAuctionV2.Contract.NftAuctionV2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NftAuctionV2 *NftAuctionV2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.NftAuctionV2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NftAuctionV2 *NftAuctionV2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.NftAuctionV2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_NftAuctionV2 *NftAuctionV2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _NftAuctionV2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_NftAuctionV2 *NftAuctionV2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_NftAuctionV2 *NftAuctionV2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.contract.Transact(opts, method, params...)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_NftAuctionV2 *NftAuctionV2Caller) Admin(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _NftAuctionV2.contract.Call(opts, &out, "admin")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_NftAuctionV2 *NftAuctionV2Session) Admin() (common.Address, error) {
+	return _NftAuctionV2.Contract.Admin(&_NftAuctionV2.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_NftAuctionV2 *NftAuctionV2CallerSession) Admin() (common.Address, error) {
+	return _NftAuctionV2.Contract.Admin(&_NftAuctionV2.CallOpts)
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 ) view returns(address seller, uint256 startPrice, uint256 startTime, uint256 duration, bool isend, address highestBidder, uint256 hightestPrice, address nftContract, uint256 tokenId, address tokenAddress)
+func (_NftAuctionV2 *NftAuctionV2Caller) Auctions(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Seller        common.Address
+	StartPrice    *big.Int
+	StartTime     *big.Int
+	Duration      *big.Int
+	Isend         bool
+	HighestBidder common.Address
+	HightestPrice *big.Int
+	NftContract   common.Address
+	TokenId       *big.Int
+	TokenAddress  common.Address
+}, error) {
+	var out []interface{}
+	err := _NftAuctionV2.contract.Call(opts, &out, "auctions", arg0)
+
+	outstruct := new(struct {
+		Seller        common.Address
+		StartPrice    *big.Int
+		StartTime     *big.Int
+		Duration      *big.Int
+		Isend         bool
+		HighestBidder common.Address
+		HightestPrice *big.Int
+		NftContract   common.Address
+		TokenId       *big.Int
+		TokenAddress  common.Address
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Seller = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.StartPrice = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.StartTime = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.Duration = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Isend = *abi.ConvertType(out[4], new(bool)).(*bool)
+	outstruct.HighestBidder = *abi.ConvertType(out[5], new(common.Address)).(*common.Address)
+	outstruct.HightestPrice = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.NftContract = *abi.ConvertType(out[7], new(common.Address)).(*common.Address)
+	outstruct.TokenId = *abi.ConvertType(out[8], new(*big.Int)).(**big.Int)
+	outstruct.TokenAddress = *abi.ConvertType(out[9], new(common.Address)).(*common.Address)
+
+	return *outstruct, err
+
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 ) view returns(address seller, uint256 startPrice, uint256 startTime, uint256 duration, bool isend, address highestBidder, uint256 hightestPrice, address nftContract, uint256 tokenId, address tokenAddress)
+func (_NftAuctionV2 *NftAuctionV2Session) Auctions(arg0 *big.Int) (struct {
+	Seller        common.Address
+	StartPrice    *big.Int
+	StartTime     *big.Int
+	Duration      *big.Int
+	Isend         bool
+	HighestBidder common.Address
+	HightestPrice *big.Int
+	NftContract   common.Address
+	TokenId       *big.Int
+	TokenAddress  common.Address
+}, error) {
+	return _NftAuctionV2.Contract.Auctions(&_NftAuctionV2.CallOpts, arg0)
+}
+
+// Auctions is a free data retrieval call binding the contract method 0x571a26a0.
+//
+// Solidity: function auctions(uint256 ) view returns(address seller, uint256 startPrice, uint256 startTime, uint256 duration, bool isend, address highestBidder, uint256 hightestPrice, address nftContract, uint256 tokenId, address tokenAddress)
+func (_NftAuctionV2 *NftAuctionV2CallerSession) Auctions(arg0 *big.Int) (struct {
+	Seller        common.Address
+	StartPrice    *big.Int
+	StartTime     *big.Int
+	Duration      *big.Int
+	Isend         bool
+	HighestBidder common.Address
+	HightestPrice *big.Int
+	NftContract   common.Address
+	TokenId       *big.Int
+	TokenAddress  common.Address
+}, error) {
+	return _NftAuctionV2.Contract.Auctions(&_NftAuctionV2.CallOpts, arg0)
+}
+
+// NextAuctionId is a free data retrieval call binding the contract method 0xfc528482.
+//
+// Solidity: function nextAuctionId() view returns(uint256)
+func (_NftAuctionV2 *NftAuctionV2Caller) NextAuctionId(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NftAuctionV2.contract.Call(opts, &out, "nextAuctionId")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextAuctionId is a free data retrieval call binding the contract method 0xfc528482.
+//
+// Solidity: function nextAuctionId() view returns(uint256)
+func (_NftAuctionV2 *NftAuctionV2Session) NextAuctionId() (*big.Int, error) {
+	return _NftAuctionV2.Contract.NextAuctionId(&_NftAuctionV2.CallOpts)
+}
+
+// NextAuctionId is a free data retrieval call binding the contract method 0xfc528482.
+//
+// Solidity: function nextAuctionId() view returns(uint256)
+func (_NftAuctionV2 *NftAuctionV2CallerSession) NextAuctionId() (*big.Int, error) {
+	return _NftAuctionV2.Contract.NextAuctionId(&_NftAuctionV2.CallOpts)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xb1cb48ef.
+//
+// Solidity: function createAuction(uint256 _duration, uint256 _startPrice, address _nftAddress, uint256 _tokenId) returns()
+func (_NftAuctionV2 *NftAuctionV2Transactor) CreateAuction(opts *bind.TransactOpts, _duration *big.Int, _startPrice *big.Int, _nftAddress common.Address, _tokenId *big.Int) (*types.Transaction, error) {
+	return _NftAuctionV2.contract.Transact(opts, "createAuction", _duration, _startPrice, _nftAddress, _tokenId)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xb1cb48ef.
+//
+// Solidity: function createAuction(uint256 _duration, uint256 _startPrice, address _nftAddress, uint256 _tokenId) returns()
+func (_NftAuctionV2 *NftAuctionV2Session) CreateAuction(_duration *big.Int, _startPrice *big.Int, _nftAddress common.Address, _tokenId *big.Int) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.CreateAuction(&_NftAuctionV2.TransactOpts, _duration, _startPrice, _nftAddress, _tokenId)
+}
+
+// CreateAuction is a paid mutator transaction binding the contract method 0xb1cb48ef.
+//
+// Solidity: function createAuction(uint256 _duration, uint256 _startPrice, address _nftAddress, uint256 _tokenId) returns()
+func (_NftAuctionV2 *NftAuctionV2TransactorSession) CreateAuction(_duration *big.Int, _startPrice *big.Int, _nftAddress common.Address, _tokenId *big.Int) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.CreateAuction(&_NftAuctionV2.TransactOpts, _duration, _startPrice, _nftAddress, _tokenId)
+}
+
+// EndAuction is a paid mutator transaction binding the contract method 0xb9a2de3a.
+//
+// Solidity: function endAuction(uint256 _auctionID) returns()
+func (_NftAuctionV2 *NftAuctionV2Transactor) EndAuction(opts *bind.TransactOpts, _auctionID *big.Int) (*types.Transaction, error) {
+	return _NftAuctionV2.contract.Transact(opts, "endAuction", _auctionID)
+}
+
+// EndAuction is a paid mutator transaction binding the contract method 0xb9a2de3a.
+//
+// Solidity: function endAuction(uint256 _auctionID) returns()
+func (_NftAuctionV2 *NftAuctionV2Session) EndAuction(_auctionID *big.Int) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.EndAuction(&_NftAuctionV2.TransactOpts, _auctionID)
+}
+
+// EndAuction is a paid mutator transaction binding the contract method 0xb9a2de3a.
+//
+// Solidity: function endAuction(uint256 _auctionID) returns()
+func (_NftAuctionV2 *NftAuctionV2TransactorSession) EndAuction(_auctionID *big.Int) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.EndAuction(&_NftAuctionV2.TransactOpts, _auctionID)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_NftAuctionV2 *NftAuctionV2Transactor) Initialize(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _NftAuctionV2.contract.Transact(opts, "initialize")
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_NftAuctionV2 *NftAuctionV2Session) Initialize() (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.Initialize(&_NftAuctionV2.TransactOpts)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_NftAuctionV2 *NftAuctionV2TransactorSession) Initialize() (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.Initialize(&_NftAuctionV2.TransactOpts)
+}
+
+// PlaceBid is a paid mutator transaction binding the contract method 0xad6561ec.
+//
+// Solidity: function placeBid(uint256 _auctionID, uint256 amount, address _tokenAddress) payable returns()
+func (_NftAuctionV2 *NftAuctionV2Transactor) PlaceBid(opts *bind.TransactOpts, _auctionID *big.Int, amount *big.Int, _tokenAddress common.Address) (*types.Transaction, error) {
+	return _NftAuctionV2.contract.Transact(opts, "placeBid", _auctionID, amount, _tokenAddress)
+}
+
+// PlaceBid is a paid mutator transaction binding the contract method 0xad6561ec.
+//
+// Solidity: function placeBid(uint256 _auctionID, uint256 amount, address _tokenAddress) payable returns()
+func (_NftAuctionV2 *NftAuctionV2Session) PlaceBid(_auctionID *big.Int, amount *big.Int, _tokenAddress common.Address) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.PlaceBid(&_NftAuctionV2.TransactOpts, _auctionID, amount, _tokenAddress)
+}
+
+// PlaceBid is a paid mutator transaction binding the contract method 0xad6561ec.
+//
+// Solidity: function placeBid(uint256 _auctionID, uint256 amount, address _tokenAddress) payable returns()
+func (_NftAuctionV2 *NftAuctionV2TransactorSession) PlaceBid(_auctionID *big.Int, amount *big.Int, _tokenAddress common.Address) (*types.Transaction, error) {
+	return _NftAuctionV2.Contract.PlaceBid(&_NftAuctionV2.TransactOpts, _auctionID, amount, _tokenAddress)
+}
+
+// NftAuctionV2InitializedIterator is returned from FilterInitialized and is used to iterate over the raw logs and unpacked data for Initialized events raised by the NftAuctionV2 contract.
+type NftAuctionV2InitializedIterator struct {
+	Event *NftAuctionV2Initialized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NftAuctionV2InitializedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NftAuctionV2Initialized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NftAuctionV2Initialized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NftAuctionV2InitializedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NftAuctionV2InitializedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NftAuctionV2Initialized represents a Initialized event raised by the NftAuctionV2 contract.
+type NftAuctionV2Initialized struct {
+	Version uint64
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterInitialized is a free log retrieval operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_NftAuctionV2 *NftAuctionV2Filterer) FilterInitialized(opts *bind.FilterOpts) (*NftAuctionV2InitializedIterator, error) {
+
+	logs, sub, err := _NftAuctionV2.contract.FilterLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return &NftAuctionV2InitializedIterator{contract: _NftAuctionV2.contract, event: "Initialized", logs: logs, sub: sub}, nil
+}
+
+// WatchInitialized is a free log subscription operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_NftAuctionV2 *NftAuctionV2Filterer) WatchInitialized(opts *bind.WatchOpts, sink chan<- *NftAuctionV2Initialized) (event.Subscription, error) {
+
+	logs, sub, err := _NftAuctionV2.contract.WatchLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NftAuctionV2Initialized)
+				if err := _NftAuctionV2.contract.UnpackLog(event, "Initialized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseInitialized is a log parse operation binding the contract event 0xc7f505b2f371ae2175ee4913f4499e1f2633a7b5936321eed1cdaeb6115181d2.
+//
+// Solidity: event Initialized(uint64 version)
+func (_NftAuctionV2 *NftAuctionV2Filterer) ParseInitialized(log types.Log) (*NftAuctionV2Initialized, error) {
+	event := new(NftAuctionV2Initialized)
+	if err := _NftAuctionV2.contract.UnpackLog(event, "Initialized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
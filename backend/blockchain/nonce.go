@@ -0,0 +1,134 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxNonceAttempts nonce 冲突时的最大重试次数
+const maxNonceAttempts = 3
+
+// nonces 全局 nonce 管理，请求之间共享，保证同一账户的 nonce 分配串行
+var nonces = newNonceManager()
+
+// nonceManager 按链和地址管理服务端账户的 nonce，同一账户在不同链上的 nonce 相互独立
+type nonceManager struct {
+	mu       sync.Mutex
+	accounts map[nonceKey]*accountNonce
+}
+
+// nonceKey 账户所在的链和地址
+type nonceKey struct {
+	chainID uint64
+	address common.Address
+}
+
+// accountNonce 单个账户的 nonce 状态，mu 在整个签名和广播期间持有
+type accountNonce struct {
+	mu     sync.Mutex
+	next   uint64
+	synced bool // false 表示需要从节点重新读取
+}
+
+func newNonceManager() *nonceManager {
+	return &nonceManager{accounts: make(map[nonceKey]*accountNonce)}
+}
+
+// account 获取账户在链上的 nonce 状态，不存在时创建
+func (m *nonceManager) account(chainID uint64, address common.Address) *accountNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := nonceKey{chainID: chainID, address: address}
+	acc, ok := m.accounts[key]
+	if !ok {
+		acc = &accountNonce{}
+		m.accounts[key] = acc
+	}
+	return acc
+}
+
+// submit 为账户分配 nonce 并执行 send，send 成功后 nonce 才被消耗
+// nonce 过低时从节点重新同步，nonce 已被交易池中的交易占用时跳到下一个
+func (m *nonceManager) submit(ctx context.Context, client *RPCPool, address common.Address, send func(nonce uint64) error) error {
+	acc := m.account(client.chainID, address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	var err error
+	for attempt := 1; attempt <= maxNonceAttempts; attempt++ {
+		if err = acc.sync(ctx, client, address); err != nil {
+			return err
+		}
+
+		nonce := acc.next
+		err = send(nonce)
+		switch {
+		case err == nil || isAlreadyKnown(err):
+			// 完全相同的交易已在交易池中，等同于提交成功
+			acc.next = nonce + 1
+			return nil
+		case isNonceTooLow(err):
+			// 账户在别处发过交易，以节点为准重新同步
+			log.Printf("Nonce %d too low for %s, resyncing\n", nonce, address.Hex())
+			acc.synced = false
+		case isNonceInUse(err):
+			// 交易池中已有该 nonce 的其他交易（例如之前广播超时但实际已提交），使用下一个
+			log.Printf("Nonce %d already in use for %s, skipping\n", nonce, address.Hex())
+			acc.next = nonce + 1
+		default:
+			return err
+		}
+	}
+	return fmt.Errorf("failed to allocate nonce for %s: %w", address.Hex(), err)
+}
+
+// replace 在账户锁内发送替换交易，替换交易沿用原 nonce，不消耗新的 nonce
+func (m *nonceManager) replace(chainID uint64, address common.Address, send func() error) error {
+	acc := m.account(chainID, address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	return send()
+}
+
+// reset 使账户下次分配 nonce 时从节点重新同步，用于交易被丢弃后填补空缺
+func (m *nonceManager) reset(chainID uint64, address common.Address) {
+	acc := m.account(chainID, address)
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+	acc.synced = false
+}
+
+// sync 与节点的 pending nonce 对齐：未同步时以节点为准，节点更大时说明账户在别处发过交易
+func (acc *accountNonce) sync(ctx context.Context, client *RPCPool, address common.Address) error {
+	pending, err := client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+	if !acc.synced || pending > acc.next {
+		acc.next = pending
+		acc.synced = true
+	}
+	return nil
+}
+
+// isNonceTooLow 判断是否为 nonce 过低错误
+func isNonceTooLow(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
+
+// isNonceInUse 判断是否为同一 nonce 已有其他交易在交易池中的错误
+func isNonceInUse(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "replacement transaction underpriced")
+}
+
+// isAlreadyKnown 判断是否为相同交易已在交易池中的错误
+func isAlreadyKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
@@ -0,0 +1,227 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var testAccount = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+// pendingNonces 按调用顺序返回节点的 pending nonce，用完后重复最后一个
+type pendingNonces struct {
+	mu     sync.Mutex
+	values []uint64
+	calls  int
+}
+
+func (p *pendingNonces) handler(params []json.RawMessage) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := p.calls
+	if i >= len(p.values) {
+		i = len(p.values) - 1
+	}
+	p.calls++
+	return hexutil.Uint64(p.values[i]), nil
+}
+
+func newNoncePool(t *testing.T, chainID uint64, pending ...uint64) *RPCPool {
+	t.Helper()
+	p := &pendingNonces{values: pending}
+	return newTestPool(t, chainID, map[string]rpcHandler{"eth_getTransactionCount": p.handler})
+}
+
+// scriptedSend 依次返回 results 中的错误，并记录每次使用的 nonce
+func scriptedSend(results []error, used *[]uint64) func(uint64) error {
+	return func(nonce uint64) error {
+		*used = append(*used, nonce)
+		if len(*used) > len(results) {
+			return nil
+		}
+		return results[len(*used)-1]
+	}
+}
+
+func TestNonceManagerSubmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		pending  []uint64
+		local    *accountNonce // 提交前的本地状态，nil 表示首次使用
+		results  []error
+		wantUsed []uint64
+		wantNext uint64
+		wantErr  bool
+	}{
+		{
+			name:     "first submit syncs from node",
+			pending:  []uint64{5},
+			results:  []error{nil},
+			wantUsed: []uint64{5},
+			wantNext: 6,
+		},
+		{
+			name:     "already known counts as sent",
+			pending:  []uint64{5},
+			results:  []error{errors.New("already known")},
+			wantUsed: []uint64{5},
+			wantNext: 6,
+		},
+		{
+			name:     "nonce too low resyncs from node",
+			pending:  []uint64{5, 8},
+			results:  []error{errors.New("nonce too low: next nonce 8, tx nonce 5"), nil},
+			wantUsed: []uint64{5, 8},
+			wantNext: 9,
+		},
+		{
+			name:     "replacement underpriced skips to next nonce",
+			pending:  []uint64{5},
+			results:  []error{errors.New("replacement transaction underpriced"), nil},
+			wantUsed: []uint64{5, 6},
+			wantNext: 7,
+		},
+		{
+			name:     "other errors do not consume the nonce",
+			pending:  []uint64{5},
+			results:  []error{errors.New("insufficient funds for gas * price + value")},
+			wantUsed: []uint64{5},
+			wantNext: 5,
+			wantErr:  true,
+		},
+		{
+			name:    "attempts exhausted",
+			pending: []uint64{5},
+			results: []error{
+				errors.New("nonce too low"),
+				errors.New("nonce too low"),
+				errors.New("nonce too low"),
+			},
+			wantUsed: []uint64{5, 5, 5},
+			wantNext: 5,
+			wantErr:  true,
+		},
+		{
+			name:     "local nonce ahead of lagging node is kept",
+			pending:  []uint64{5},
+			local:    &accountNonce{next: 10, synced: true},
+			results:  []error{nil},
+			wantUsed: []uint64{10},
+			wantNext: 11,
+		},
+		{
+			name:     "node ahead of local nonce wins",
+			pending:  []uint64{7},
+			local:    &accountNonce{next: 3, synced: true},
+			results:  []error{nil},
+			wantUsed: []uint64{7},
+			wantNext: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t, config.Config{})
+			pool := newNoncePool(t, 1, tt.pending...)
+			m := newNonceManager()
+			if tt.local != nil {
+				m.accounts[nonceKey{chainID: 1, address: testAccount}] = tt.local
+			}
+
+			var used []uint64
+			err := m.submit(context.Background(), pool, testAccount, scriptedSend(tt.results, &used))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("submit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !equalNonces(used, tt.wantUsed) {
+				t.Errorf("used nonces = %v, want %v", used, tt.wantUsed)
+			}
+			if next := m.account(1, testAccount).next; next != tt.wantNext {
+				t.Errorf("next = %d, want %d", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestNonceManagerReset(t *testing.T) {
+	setTestConfig(t, config.Config{})
+	pool := newNoncePool(t, 1, 5, 4)
+	m := newNonceManager()
+
+	var used []uint64
+	send := scriptedSend(nil, &used)
+	if err := m.submit(context.Background(), pool, testAccount, send); err != nil {
+		t.Fatalf("submit() error = %v", err)
+	}
+	// 交易被丢弃后重置，下次以节点的 pending nonce 为准，即使它比本地小
+	m.reset(1, testAccount)
+	if err := m.submit(context.Background(), pool, testAccount, send); err != nil {
+		t.Fatalf("submit() error = %v", err)
+	}
+	if want := []uint64{5, 4}; !equalNonces(used, want) {
+		t.Errorf("used nonces = %v, want %v", used, want)
+	}
+}
+
+func TestNonceManagerPerChain(t *testing.T) {
+	setTestConfig(t, config.Config{})
+	mainnet := newNoncePool(t, 1, 5)
+	polygon := newNoncePool(t, 137, 40)
+	m := newNonceManager()
+
+	var used []uint64
+	send := scriptedSend(nil, &used)
+	for _, pool := range []*RPCPool{mainnet, polygon, mainnet, polygon} {
+		if err := m.submit(context.Background(), pool, testAccount, send); err != nil {
+			t.Fatalf("submit() error = %v", err)
+		}
+	}
+	if want := []uint64{5, 40, 6, 41}; !equalNonces(used, want) {
+		t.Errorf("used nonces = %v, want %v", used, want)
+	}
+}
+
+func TestNonceErrorClassification(t *testing.T) {
+	tests := []struct {
+		msg                         string
+		tooLow, inUse, alreadyKnown bool
+	}{
+		{msg: "nonce too low", tooLow: true},
+		{msg: "Nonce too low: next nonce 8, tx nonce 5", tooLow: true},
+		{msg: "replacement transaction underpriced", inUse: true},
+		{msg: "already known", alreadyKnown: true},
+		{msg: "known transaction: 0xabc", alreadyKnown: true},
+		{msg: "insufficient funds for gas * price + value"},
+	}
+
+	for _, tt := range tests {
+		err := errors.New(tt.msg)
+		if got := isNonceTooLow(err); got != tt.tooLow {
+			t.Errorf("isNonceTooLow(%q) = %v, want %v", tt.msg, got, tt.tooLow)
+		}
+		if got := isNonceInUse(err); got != tt.inUse {
+			t.Errorf("isNonceInUse(%q) = %v, want %v", tt.msg, got, tt.inUse)
+		}
+		if got := isAlreadyKnown(err); got != tt.alreadyKnown {
+			t.Errorf("isAlreadyKnown(%q) = %v, want %v", tt.msg, got, tt.alreadyKnown)
+		}
+	}
+}
+
+func equalNonces(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
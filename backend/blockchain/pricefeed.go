@@ -0,0 +1,219 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm/clause"
+)
+
+// Chainlink AggregatorV3Interface 绑定由 abi/AggregatorV3.abi.json 生成
+//go:generate abigen --abi abi/AggregatorV3.abi.json --pkg blockchain --type AggregatorV3 --out aggregator.go
+
+// 预言机状态
+const (
+	FeedStatusOK         = "ok"         // 报价正常
+	FeedStatusMissing    = "missing"    // 未配置预言机，placeBid 会 revert
+	FeedStatusUnreadable = "unreadable" // 无法读取 latestRoundData，placeBid 会 revert
+	FeedStatusInvalid    = "invalid"    // 报价不为正数，合约转换为 uint 后计算溢出，placeBid 会 revert
+	FeedStatusStale      = "stale"      // 报价超过 PRICE_FEED_MAX_AGE 未更新，合约不校验但出价按过期价格比较
+)
+
+// ErrInvalidPriceFeed 预言机地址无法提供有效报价
+var ErrInvalidPriceFeed = errors.New("invalid price feed")
+
+// FeedStatus 代币的预言机配置和最新报价
+type FeedStatus struct {
+	TokenAddress string `json:"token_address"`
+	FeedAddress  string `json:"feed_address"`
+	Description  string `json:"description,omitempty"`
+	Decimals     uint8  `json:"decimals"`
+	Answer       string `json:"answer,omitempty"`     // 最新报价（按 decimals 缩放的整数）
+	RoundID      string `json:"round_id,omitempty"`   // 最新轮次
+	UpdatedAt    uint64 `json:"updated_at,omitempty"` // 最新报价时间（秒）
+	Age          int64  `json:"age"`                  // 距最新区块时间（秒）
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+// BlocksBids 该状态是否会导致使用此代币的出价 revert
+func (s *FeedStatus) BlocksBids() bool {
+	return s.Status != FeedStatusOK && s.Status != FeedStatusStale
+}
+
+// PriceFeed 读取合约中代币对应的预言机地址，未配置时为零地址
+func (cs *ContractService) PriceFeed(ctx context.Context, tokenAddress common.Address) (common.Address, error) {
+	feed, err := cs.auction.PriceFeeds(&bind.CallOpts{Context: ctx}, tokenAddress)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to call contract: %w", err)
+	}
+	return feed, nil
+}
+
+// FeedStatus 读取代币的预言机配置和最新报价，预言机本身的问题记录在返回的状态中
+func (cs *ContractService) FeedStatus(ctx context.Context, tokenAddress common.Address) (*FeedStatus, error) {
+	feed, err := cs.PriceFeed(ctx, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	head, err := cs.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	status := &FeedStatus{
+		TokenAddress: strings.ToLower(tokenAddress.Hex()),
+		FeedAddress:  strings.ToLower(feed.Hex()),
+	}
+	if feed == (common.Address{}) {
+		status.Status = FeedStatusMissing
+		return status, nil
+	}
+	cs.readFeed(ctx, feed, head.Time, status)
+	return status, nil
+}
+
+// readFeed 读取预言机的精度、描述和最新一轮报价
+func (cs *ContractService) readFeed(ctx context.Context, feed common.Address, now uint64, status *FeedStatus) {
+	aggregator, err := NewAggregatorV3Caller(feed, cs.client)
+	if err != nil {
+		status.Status = FeedStatusUnreadable
+		status.Error = err.Error()
+		return
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	round, err := aggregator.LatestRoundData(opts)
+	if err != nil {
+		status.Status = FeedStatusUnreadable
+		status.Error = err.Error()
+		return
+	}
+	if decimals, err := aggregator.Decimals(opts); err == nil {
+		status.Decimals = decimals
+	}
+	// description 仅用于展示，读取失败时忽略
+	if description, err := aggregator.Description(opts); err == nil {
+		status.Description = description
+	}
+
+	status.Answer = round.Answer.String()
+	status.RoundID = round.RoundId.String()
+	status.UpdatedAt = round.UpdatedAt.Uint64()
+	status.Age = int64(now) - int64(status.UpdatedAt)
+
+	switch {
+	case round.Answer.Sign() <= 0:
+		status.Status = FeedStatusInvalid
+	case status.Age > int64(config.AppConfig.PriceFeedMaxAge):
+		status.Status = FeedStatusStale
+	default:
+		status.Status = FeedStatusOK
+	}
+}
+
+// ListPriceFeeds 列出已知代币的预言机状态
+// 合约的 priceFeeds 映射不可遍历，已知代币包括 ETH、通过后端设置过预言机的代币以及拍卖和出价中出现过的代币
+func (cs *ContractService) ListPriceFeeds(ctx context.Context) ([]*FeedStatus, error) {
+	tokens, err := cs.knownFeedTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*FeedStatus, 0, len(tokens))
+	for _, token := range tokens {
+		status, err := cs.FeedStatus(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read price feed for %s: %w", token.Hex(), err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// knownFeedTokens 汇总本合约需要检查预言机的代币地址，ETH 排在最前
+func (cs *ContractService) knownFeedTokens() ([]common.Address, error) {
+	db := database.GetDB()
+	var addresses []string
+
+	var configured []string
+	if err := db.Model(&models.PriceFeed{}).Where("chain_id = ?", cs.chainID).Order("token_address").Pluck("token_address", &configured).Error; err != nil {
+		return nil, fmt.Errorf("failed to load price feeds: %w", err)
+	}
+	addresses = append(addresses, configured...)
+
+	var bidTokens []string
+	if err := db.Model(&models.Bid{}).Scopes(cs.scope()).Distinct("token_address").Pluck("token_address", &bidTokens).Error; err != nil {
+		return nil, fmt.Errorf("failed to load bid tokens: %w", err)
+	}
+	addresses = append(addresses, bidTokens...)
+
+	var auctionTokens []string
+	if err := db.Model(&models.Auction{}).Scopes(cs.scope()).Where("token_address <> ''").Distinct("token_address").
+		Pluck("token_address", &auctionTokens).Error; err != nil {
+		return nil, fmt.Errorf("failed to load auction tokens: %w", err)
+	}
+	addresses = append(addresses, auctionTokens...)
+
+	tokens := []common.Address{{}}
+	seen := map[common.Address]bool{{}: true}
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			continue
+		}
+		token := common.HexToAddress(address)
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// SetPriceFeed 以服务端签名账户调用 setPriceFeed，预言机必须能提供有效报价
+func (cs *ContractService) SetPriceFeed(ctx context.Context, tokenAddress, feed common.Address) (*types.Transaction, *FeedStatus, error) {
+	head, err := cs.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	// 设置前先读取一次，避免配置后所有使用该代币的出价都 revert
+	status := &FeedStatus{
+		TokenAddress: strings.ToLower(tokenAddress.Hex()),
+		FeedAddress:  strings.ToLower(feed.Hex()),
+	}
+	cs.readFeed(ctx, feed, head.Time, status)
+	if status.BlocksBids() {
+		return nil, status, fmt.Errorf("%w: feed %s is %s", ErrInvalidPriceFeed, feed.Hex(), status.Status)
+	}
+
+	tx, err := cs.Transact(ctx, big.NewInt(0), "setPriceFeed", tokenAddress, feed)
+	if err != nil {
+		return nil, status, err
+	}
+
+	// 交易已广播，记录失败只影响预言机列表
+	record := models.PriceFeed{
+		ChainID:      cs.chainID,
+		TokenAddress: status.TokenAddress,
+		FeedAddress:  status.FeedAddress,
+		TxHash:       tx.Hash().Hex(),
+	}
+	if err := database.GetDB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "token_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"feed_address", "tx_hash", "updated_at"}),
+	}).Create(&record).Error; err != nil {
+		log.Printf("Failed to record price feed for %s: %v\n", tokenAddress.Hex(), err)
+	}
+	return tx, status, nil
+}
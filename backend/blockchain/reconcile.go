@@ -0,0 +1,250 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+// 对账触发方式
+const (
+	ReconcileScheduled = "schedule"
+	ReconcileManual    = "manual"
+)
+
+// ErrReconcileRunning 已有对账正在运行
+var ErrReconcileRunning = errors.New("reconciliation already running")
+
+// reconcilers 注册表中各合约的对账实例，按 IndexerKey 索引，供管理接口手动触发
+var (
+	reconcilersMu sync.RWMutex
+	reconcilers   = make(map[string]*Reconciler)
+)
+
+// Reconciler 链上对账，定期将未结束的拍卖与合约 auctions(i) 比对并修复差异
+type Reconciler struct {
+	contract *ContractService
+	running  sync.Mutex
+}
+
+// NewReconciler 创建对账实例
+func NewReconciler(contract *ContractService) *Reconciler {
+	return &Reconciler{contract: contract}
+}
+
+// SetReconciler 登记合约的对账实例
+func SetReconciler(r *Reconciler) {
+	reconcilersMu.Lock()
+	defer reconcilersMu.Unlock()
+	reconcilers[IndexerKey(r.contract.chainID, r.contract.contractKey())] = r
+}
+
+// GetReconciler 获取链上合约的对账实例，未启动时返回 nil
+func GetReconciler(chainID uint64, address string) *Reconciler {
+	reconcilersMu.RLock()
+	defer reconcilersMu.RUnlock()
+	return reconcilers[IndexerKey(chainID, address)]
+}
+
+// removeReconciler 注销合约的对账实例
+func removeReconciler(key string) {
+	reconcilersMu.Lock()
+	defer reconcilersMu.Unlock()
+	delete(reconcilers, key)
+}
+
+// Run 按 RECONCILE_INTERVAL 定期对账，直到 ctx 取消
+func (r *Reconciler) Run(ctx context.Context) {
+	interval := time.Duration(config.AppConfig.ReconcileInterval) * time.Second
+	if interval <= 0 {
+		log.Println("Auction reconciliation disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			report, err := r.Reconcile(ctx, ReconcileScheduled)
+			if err != nil {
+				log.Printf("Auction reconciliation failed: %v\n", err)
+			} else if report.Mismatched > 0 {
+				log.Printf("Auction reconciliation report %d: %d checked, %d mismatched, %d repaired\n",
+					report.ID, report.Checked, report.Mismatched, report.Repaired)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Reconcile 执行一次对账，返回本次生成的报告
+func (r *Reconciler) Reconcile(ctx context.Context, triggeredBy string) (*models.ReconcileReport, error) {
+	if !r.running.TryLock() {
+		return nil, ErrReconcileRunning
+	}
+	defer r.running.Unlock()
+
+	db := database.GetDB()
+	report := models.ReconcileReport{
+		ChainID:         r.contract.chainID,
+		ContractAddress: r.contract.contractKey(),
+		TriggeredBy:     triggeredBy,
+		StartedAt:       time.Now(),
+	}
+
+	head, err := r.contract.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	report.BlockNumber = head
+
+	if err := db.Create(&report).Error; err != nil {
+		return nil, fmt.Errorf("failed to create reconcile report: %w", err)
+	}
+
+	runErr := r.reconcileAuctions(ctx, &report)
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+
+	finishedAt := time.Now()
+	report.FinishedAt = &finishedAt
+	if err := db.Save(&report).Error; err != nil {
+		return nil, fmt.Errorf("failed to save reconcile report: %w", err)
+	}
+	return &report, runErr
+}
+
+// reconcileAuctions 逐个比对未结束的拍卖
+func (r *Reconciler) reconcileAuctions(ctx context.Context, report *models.ReconcileReport) error {
+	var auctions []models.Auction
+	if err := database.GetDB().Scopes(r.contract.scope()).Where("ended = ?", false).
+		Order("auction_id ASC").Find(&auctions).Error; err != nil {
+		return fmt.Errorf("failed to load auctions: %w", err)
+	}
+
+	for i := range auctions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		info, err := r.contract.GetAuctionInfo(ctx, new(big.Int).SetUint64(uint64(auctions[i].AuctionID)))
+		if err != nil {
+			return fmt.Errorf("failed to read auction %d: %w", auctions[i].AuctionID, err)
+		}
+		report.Checked++
+
+		discrepancies := diffAuction(&auctions[i], info)
+		if len(discrepancies) == 0 {
+			continue
+		}
+		report.Mismatched++
+
+		repaired, err := repairAuction(&auctions[i], info, report, discrepancies)
+		if err != nil {
+			return err
+		}
+		if repaired {
+			report.Repaired++
+		}
+	}
+	return nil
+}
+
+// diffAuction 比较本地拍卖与合约数据，返回不一致的字段
+func diffAuction(auction *models.Auction, info map[string]interface{}) []models.AuctionDiscrepancy {
+	var discrepancies []models.AuctionDiscrepancy
+	add := func(field, local, chain string) {
+		if local != chain {
+			discrepancies = append(discrepancies, models.AuctionDiscrepancy{
+				AuctionID:  auction.AuctionID,
+				Field:      field,
+				LocalValue: local,
+				ChainValue: chain,
+			})
+		}
+	}
+
+	// 合约中卖家为零地址说明该拍卖在链上不存在
+	if info["seller"].(string) == (common.Address{}).Hex() {
+		add("exists", "true", "false")
+		return discrepancies
+	}
+
+	bidder, bid := chainHighestBid(info)
+	add("highest_bidder", auction.HighestBidder, bidder)
+	add("highest_bid", auction.HighestBid, bid)
+	add("ended", strconv.FormatBool(auction.Ended), strconv.FormatBool(info["ended"].(bool)))
+	return discrepancies
+}
+
+// chainHighestBid 取合约中的最高出价，尚无出价时与本地一致返回空字符串
+func chainHighestBid(info map[string]interface{}) (string, string) {
+	bidder := info["highest_bidder"].(string)
+	if bidder == (common.Address{}).Hex() {
+		return "", ""
+	}
+	return strings.ToLower(bidder), info["highest_bid"].(string)
+}
+
+// repairAuction 以合约数据修复最高出价和结束状态，并记录差异
+// 链上不存在的拍卖只记录不修复，需要人工处理
+func repairAuction(auction *models.Auction, info map[string]interface{}, report *models.ReconcileReport, discrepancies []models.AuctionDiscrepancy) (bool, error) {
+	repairable := discrepancies[0].Field != "exists"
+
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		if repairable {
+			bidder, bid := chainHighestBid(info)
+			updates := map[string]interface{}{
+				"highest_bidder": bidder,
+				"highest_bid":    bid,
+				"ended":          info["ended"].(bool),
+				"confirmed":      false,
+			}
+			if bidder != "" {
+				updates["token_address"] = strings.ToLower(info["token_address"].(string))
+			}
+			// 结束所在区块未知，以对账时的区块近似，便于确认深度计算
+			if info["ended"].(bool) && auction.EndedBlock == nil {
+				updates["ended_block"] = report.BlockNumber
+			}
+			if err := tx.Model(&models.Auction{}).
+				Scopes(chainContractScope(auction.ChainID, auction.ContractAddress)).
+				Where("auction_id = ?", auction.AuctionID).
+				Updates(updates).Error; err != nil {
+				return fmt.Errorf("failed to repair auction %d: %w", auction.AuctionID, err)
+			}
+		}
+
+		for i := range discrepancies {
+			discrepancies[i].ReportID = report.ID
+			discrepancies[i].Repaired = repairable
+		}
+		if err := tx.Create(&discrepancies).Error; err != nil {
+			return fmt.Errorf("failed to record discrepancies: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("Auction %d differs from contract in %d field(s), repaired=%t\n",
+		auction.AuctionID, len(discrepancies), repairable)
+	return repairable, nil
+}
@@ -0,0 +1,337 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrContractExists 合约已在注册表中
+	ErrContractExists = errors.New("contract already registered")
+	// ErrNoContractCode 地址上没有合约代码
+	ErrNoContractCode = errors.New("no contract code at address")
+	// ErrUnknownABIVersion 注册表中指定的 ABI 版本不存在
+	ErrUnknownABIVersion = errors.New("unknown ABI version")
+	// ErrIndexingNotStarted 尚未调用 StartIndexing
+	ErrIndexingNotStarted = errors.New("indexing is not started")
+	// ErrStopTimeout 合约的监听器、对账或自动结束拍卖未在限定时间内退出
+	ErrStopTimeout = errors.New("contract indexer did not stop in time")
+)
+
+// indexingCtx 所有监听器的父上下文，由 StartIndexing 设置
+var indexingCtx context.Context
+
+// stopTimeout 停止监听器时等待其退出的最长时间
+const stopTimeout = 30 * time.Second
+
+// RegisterContractParams 注册拍卖合约的参数
+type RegisterContractParams struct {
+	ChainID    uint64 // 为 0 时使用默认链
+	Address    common.Address
+	Name       string
+	StartBlock uint64
+	ABIVersion string // 为空时按实现历史选择
+}
+
+// ContractUpdate 修改注册表中合约的参数，nil 表示不修改
+type ContractUpdate struct {
+	Name       *string
+	Enabled    *bool
+	ABIVersion *string
+}
+
+// DefaultContractKey 默认合约（CONTRACT_ADDRESS）的地址（小写），API 未指定合约时使用
+func DefaultContractKey() string {
+	return strings.ToLower(common.HexToAddress(config.AppConfig.ContractAddress).Hex())
+}
+
+// EnsureDefaultContract 将 CONTRACT_ADDRESS 登记到默认链的合约注册表，需在 InitRPCPools 确定默认链之后调用
+// 单合约、单链时期写入的数据归属到默认链上的该合约
+func EnsureDefaultContract(ctx context.Context) error {
+	chainID := DefaultChainID()
+	if chainID == 0 {
+		return errors.New("default chain is unknown, RPC pools are not initialized")
+	}
+
+	db := database.GetDB().WithContext(ctx)
+	key := DefaultContractKey()
+	contract := models.Contract{ChainID: chainID, Address: key}
+	if err := db.Where(&contract).
+		Attrs(models.Contract{Name: "default", StartBlock: config.AppConfig.StartBlock, Enabled: true}).
+		FirstOrCreate(&contract).Error; err != nil {
+		return fmt.Errorf("failed to register default contract: %w", err)
+	}
+
+	for _, model := range []interface{}{&models.Auction{}, &models.Bid{}, &models.KeeperJob{}, &models.ReconcileReport{}} {
+		result := db.Model(model).Where("contract_address = ''").Update("contract_address", key)
+		if result.Error != nil {
+			return fmt.Errorf("failed to assign legacy rows to default contract: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("Assigned %d legacy row(s) to default contract %s\n", result.RowsAffected, key)
+		}
+	}
+
+	chainModels := []interface{}{
+		&models.Auction{}, &models.Bid{}, &models.KeeperJob{}, &models.ReconcileReport{},
+		&models.NFTMetadata{}, &models.NFTCollection{}, &models.PriceFeed{},
+	}
+	for _, model := range chainModels {
+		result := db.Model(model).Where("chain_id = 0").Update("chain_id", chainID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to assign legacy rows to default chain: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("Assigned %d legacy row(s) to default chain %d\n", result.RowsAffected, chainID)
+		}
+	}
+	return nil
+}
+
+// StartIndexing 为注册表中启用的合约各启动一个监听器，连接合约所在链的节点，ctx 取消时全部停止
+// 单个合约启动失败只记录日志，不影响其他合约
+func StartIndexing(ctx context.Context) error {
+	indexingCtx = ctx
+
+	var contracts []models.Contract
+	if err := database.GetDB().Where("enabled = ?", true).Order("id ASC").Find(&contracts).Error; err != nil {
+		return fmt.Errorf("failed to load contracts: %w", err)
+	}
+
+	for i := range contracts {
+		if !IsKnownChain(contracts[i].ChainID) {
+			log.Printf("Skipping contract %s, no RPC URL configured for chain %d\n",
+				contracts[i].Address, contracts[i].ChainID)
+			continue
+		}
+		if err := StartContract(&contracts[i]); err != nil {
+			log.Printf("Failed to start indexing %s: %v\n", contracts[i].Address, err)
+		}
+	}
+	return nil
+}
+
+// StartContract 启动单个合约的监听器、对账和自动结束拍卖，已在运行时不重复启动
+// 合约所在链还没有交易跟踪时一并启动，交易跟踪按链运行，不随合约停止
+func StartContract(contract *models.Contract) error {
+	if indexingCtx == nil {
+		return ErrIndexingNotStarted
+	}
+
+	key := IndexerKey(contract.ChainID, contract.Address)
+	supervisorsMu.Lock()
+	defer supervisorsMu.Unlock()
+	if _, ok := supervisors[key]; ok {
+		return nil
+	}
+
+	pool, err := GetRPCPool(contract.ChainID)
+	if err != nil {
+		return err
+	}
+	listener, err := NewEventListener(pool, contract)
+	if err != nil {
+		return err
+	}
+	service, err := NewContractServiceAt(pool, common.HexToAddress(contract.Address))
+	if err != nil {
+		return err
+	}
+	reconciler := NewReconciler(service)
+	keeper := NewKeeper(service)
+
+	// supervisorsMu 保证同一条链只启动一个交易跟踪
+	if GetTxTracker(contract.ChainID) == nil {
+		tracker := NewTxTracker(service)
+		SetTxTracker(tracker)
+		go tracker.Run(indexingCtx)
+	}
+
+	ctx, cancel := context.WithCancel(indexingCtx)
+	s := NewSupervisor(listener)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	supervisors[key] = s
+	SetReconciler(reconciler)
+	SetKeeper(keeper)
+
+	// 三者都退出后才视为合约已停止，避免停止后仍有 endAuction 在提交
+	var wg sync.WaitGroup
+	for _, run := range []func(context.Context){s.Run, reconciler.Run, keeper.Run} {
+		wg.Add(1)
+		go func(run func(context.Context)) {
+			defer wg.Done()
+			run(ctx)
+		}(run)
+	}
+	go func() {
+		wg.Wait()
+		close(s.done)
+	}()
+	log.Printf("Indexing contract %s (%s) from block %d\n", key, contract.Name, listener.resumeBlock())
+	return nil
+}
+
+// StopContract 停止链上合约的监听器、对账和自动结束拍卖并等待其退出，已索引的数据保留
+// 超时未退出时返回 ErrStopTimeout，合约在退出前保持登记，期间不能重新启动
+func StopContract(chainID uint64, address string) error {
+	key := IndexerKey(chainID, address)
+	supervisorsMu.RLock()
+	s, ok := supervisors[key]
+	supervisorsMu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	s.cancel()
+	select {
+	case <-s.done:
+		unregisterContract(key, s)
+		log.Printf("Stopped indexing contract %s\n", key)
+		return nil
+	case <-time.After(stopTimeout):
+		go func() {
+			<-s.done
+			unregisterContract(key, s)
+			log.Printf("Stopped indexing contract %s after timeout\n", key)
+		}()
+		return fmt.Errorf("%w: %s after %s", ErrStopTimeout, key, stopTimeout)
+	}
+}
+
+// unregisterContract 注销已退出的合约实例，已被新实例替换时不处理
+func unregisterContract(key string, s *Supervisor) {
+	supervisorsMu.Lock()
+	defer supervisorsMu.Unlock()
+	if supervisors[key] != s {
+		return
+	}
+	delete(supervisors, key)
+	removeReconciler(key)
+	removeKeeper(key)
+}
+
+// validateABIVersion 校验注册表中的 ABI 版本，空字符串表示按实现历史选择
+func validateABIVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+	if _, ok := auctionABIs[version]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownABIVersion, version)
+	}
+	return nil
+}
+
+// RegisterContract 登记新的拍卖合约并立即开始索引，合约所在链必须已配置 RPC 地址
+func RegisterContract(ctx context.Context, params RegisterContractParams) (*models.Contract, error) {
+	if err := validateABIVersion(params.ABIVersion); err != nil {
+		return nil, err
+	}
+
+	chainID := params.ChainID
+	if chainID == 0 {
+		chainID = DefaultChainID()
+	}
+	pool, err := GetRPCPool(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := pool.CodeAt(ctx, params.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract code: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoContractCode, params.Address.Hex())
+	}
+
+	contract := models.Contract{
+		ChainID:    chainID,
+		Address:    strings.ToLower(params.Address.Hex()),
+		Name:       params.Name,
+		StartBlock: params.StartBlock,
+		ABIVersion: params.ABIVersion,
+		Enabled:    true,
+	}
+
+	db := database.GetDB()
+	var count int64
+	if err := db.Model(&models.Contract{}).
+		Where("chain_id = ? AND address = ?", contract.ChainID, contract.Address).
+		Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("failed to check contract: %w", err)
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrContractExists, contract.Address)
+	}
+	if err := db.Create(&contract).Error; err != nil {
+		return nil, fmt.Errorf("failed to register contract: %w", err)
+	}
+
+	// 注册已成功，启动失败时可通过重新启用重试
+	if err := StartContract(&contract); err != nil {
+		log.Printf("Failed to start indexing %s: %v\n", contract.Address, err)
+	}
+	return &contract, nil
+}
+
+// UpdateContract 修改注册表中的合约，启用的合约会重启监听器使新配置生效，停用的合约停止索引
+func UpdateContract(id uint, update ContractUpdate) (*models.Contract, error) {
+	db := database.GetDB()
+	var contract models.Contract
+	if err := db.First(&contract, id).Error; err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		contract.Name = *update.Name
+	}
+	if update.Enabled != nil {
+		contract.Enabled = *update.Enabled
+	}
+	if update.ABIVersion != nil {
+		if err := validateABIVersion(*update.ABIVersion); err != nil {
+			return nil, err
+		}
+		contract.ABIVersion = *update.ABIVersion
+	}
+	if err := db.Save(&contract).Error; err != nil {
+		return nil, fmt.Errorf("failed to save contract: %w", err)
+	}
+
+	// 先等旧监听器退出，避免新旧监听器同时写入同一游标；未能退出时不重新启动
+	if err := StopContract(contract.ChainID, contract.Address); err != nil {
+		return &contract, err
+	}
+	if contract.Enabled {
+		if err := StartContract(&contract); err != nil {
+			return &contract, fmt.Errorf("failed to start indexing: %w", err)
+		}
+	}
+	return &contract, nil
+}
+
+// LookupContract 按链ID和地址查找注册表中的合约
+func LookupContract(chainID uint64, address string) (*models.Contract, error) {
+	var contract models.Contract
+	err := database.GetDB().Where("chain_id = ? AND address = ?", chainID, strings.ToLower(address)).
+		First(&contract).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contract: %w", err)
+	}
+	return &contract, nil
+}
@@ -0,0 +1,240 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reorgCheckInterval 实时模式下定期校验区块哈希的间隔
+const reorgCheckInterval = 15 * time.Second
+
+// recordBlock 记录已处理区块的哈希
+func (el *EventListener) recordBlock(tx *gorm.DB, blockNumber uint64, blockHash common.Hash) error {
+	block := models.IndexedBlock{
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash.Hex(),
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "block_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_hash"}),
+	}).Create(&block).Error
+	if err != nil {
+		return fmt.Errorf("failed to record block hash: %w", err)
+	}
+	return nil
+}
+
+// storedBlockHash 获取已记录的区块哈希，未记录时返回空字符串
+func (el *EventListener) storedBlockHash(blockNumber uint64) (string, error) {
+	var block models.IndexedBlock
+	err := database.GetDB().
+		Where("chain_id = ? AND contract_address = ? AND block_number = ?", el.chainID, el.contractKey(), blockNumber).
+		First(&block).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load block hash: %w", err)
+	}
+	return block.BlockHash, nil
+}
+
+// checkLogReorg 检查实时日志是否意味着发生了链重组，是则回滚并重新同步
+// 返回 true 表示日志已通过重新同步处理，调用方无需再处理
+func (el *EventListener) checkLogReorg(ctx context.Context, vLog types.Log) (bool, error) {
+	stored, err := el.storedBlockHash(vLog.BlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	if vLog.Removed {
+		// 只有当被移除的日志所在区块正是我们记录的区块时才需要回滚
+		if stored != vLog.BlockHash.Hex() {
+			return true, nil
+		}
+		log.Printf("Removed log received at block %d, rolling back\n", vLog.BlockNumber)
+		return true, el.handleReorg(ctx, vLog.BlockNumber)
+	}
+
+	if stored != "" && stored != vLog.BlockHash.Hex() {
+		log.Printf("Block hash changed at block %d (%s -> %s), rolling back\n",
+			vLog.BlockNumber, stored, vLog.BlockHash.Hex())
+		return true, el.handleReorg(ctx, vLog.BlockNumber)
+	}
+
+	return false, nil
+}
+
+// checkReorg 对比近期已记录区块与链上区块哈希，发现分叉时回滚并重新同步
+func (el *EventListener) checkReorg(ctx context.Context) error {
+	var blocks []models.IndexedBlock
+	err := database.GetDB().
+		Where("chain_id = ? AND contract_address = ?", el.chainID, el.contractKey()).
+		Order("block_number DESC").
+		Limit(int(config.AppConfig.ReorgDepth)).
+		Find(&blocks).Error
+	if err != nil {
+		return fmt.Errorf("failed to load indexed blocks: %w", err)
+	}
+
+	// 从新到旧比较，直到遇到一致的区块为止，最后一个不一致的区块即分叉点
+	var forkBlock *uint64
+	for _, block := range blocks {
+		header, err := el.client.HeaderByNumber(ctx, new(big.Int).SetUint64(block.BlockNumber))
+		if err != nil {
+			return fmt.Errorf("failed to get header %d: %w", block.BlockNumber, err)
+		}
+		if header.Hash().Hex() == block.BlockHash {
+			break
+		}
+		number := block.BlockNumber
+		forkBlock = &number
+	}
+
+	if forkBlock == nil {
+		return nil
+	}
+
+	log.Printf("Chain reorganization detected from block %d\n", *forkBlock)
+	return el.handleReorg(ctx, *forkBlock)
+}
+
+// handleReorg 回滚孤块产生的数据，然后从分叉点重新同步规范链上的日志
+func (el *EventListener) handleReorg(ctx context.Context, fromBlock uint64) error {
+	if err := el.rollback(fromBlock); err != nil {
+		return err
+	}
+	return el.backfill(ctx, fromBlock)
+}
+
+// rollback 删除 fromBlock 及之后区块派生的数据，并将游标回退到 fromBlock 之前
+func (el *EventListener) rollback(fromBlock uint64) error {
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		// 受影响的拍卖需要根据剩余出价重新计算最高价
+		scope := el.scope()
+		var affected []uint
+		if err := tx.Model(&models.Bid{}).
+			Scopes(scope).Where("block_number >= ?", fromBlock).
+			Distinct().Pluck("auction_id", &affected).Error; err != nil {
+			return fmt.Errorf("failed to find affected auctions: %w", err)
+		}
+		var reopened []uint
+		if err := tx.Model(&models.Auction{}).
+			Scopes(scope).Where("ended_block >= ?", fromBlock).
+			Pluck("auction_id", &reopened).Error; err != nil {
+			return fmt.Errorf("failed to find ended auctions: %w", err)
+		}
+		affected = append(affected, reopened...)
+
+		if err := tx.Scopes(scope).Where("block_number >= ?", fromBlock).
+			Delete(&models.Bid{}).Error; err != nil {
+			return fmt.Errorf("failed to delete orphaned bids: %w", err)
+		}
+		if err := tx.Scopes(scope).Where("created_block >= ?", fromBlock).
+			Delete(&models.Auction{}).Error; err != nil {
+			return fmt.Errorf("failed to delete orphaned auctions: %w", err)
+		}
+		if err := tx.Model(&models.Auction{}).
+			Scopes(scope).Where("ended_block >= ?", fromBlock).
+			Updates(map[string]interface{}{"ended": false, "end_time": nil, "ended_block": nil, "confirmed": false}).Error; err != nil {
+			return fmt.Errorf("failed to reopen auctions: %w", err)
+		}
+
+		for _, auctionID := range affected {
+			if err := recomputeAuction(tx, el.chainID, el.contractKey(), auctionID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("chain_id = ? AND contract_address = ? AND block_number >= ?",
+			el.chainID, el.contractKey(), fromBlock).Delete(&models.Event{}).Error; err != nil {
+			return fmt.Errorf("failed to delete orphaned events: %w", err)
+		}
+		if err := tx.Where("chain_id = ? AND contract_address = ? AND block_number >= ?",
+			el.chainID, el.contractKey(), fromBlock).Delete(&models.IndexedBlock{}).Error; err != nil {
+			return fmt.Errorf("failed to delete orphaned blocks: %w", err)
+		}
+		if err := el.rollbackImplementations(tx, fromBlock); err != nil {
+			return err
+		}
+
+		if fromBlock == 0 {
+			return tx.Where("chain_id = ? AND contract_address = ?", el.chainID, el.contractKey()).
+				Delete(&models.SyncCursor{}).Error
+		}
+		return el.saveCursor(tx, fromBlock-1, -1)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to roll back from block %d: %w", fromBlock, err)
+	}
+
+	if fromBlock == 0 {
+		el.cursor = nil
+	} else {
+		el.advanceCursor(fromBlock-1, -1)
+	}
+	log.Printf("Rolled back indexed data from block %d\n", fromBlock)
+	return nil
+}
+
+// recomputeAuction 根据剩余的出价记录重新计算拍卖的最高出价信息
+func recomputeAuction(tx *gorm.DB, chainID uint64, contract string, auctionID uint) error {
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(chainContractScope(chainID, contract)).Where("auction_id = ?", auctionID)
+	}
+
+	var bidCount int64
+	if err := tx.Model(&models.Bid{}).Scopes(scope).Count(&bidCount).Error; err != nil {
+		return fmt.Errorf("failed to count bids: %w", err)
+	}
+
+	updates := map[string]interface{}{
+		"highest_bidder": "",
+		"highest_bid":    "",
+		"token_address":  "",
+		"bid_count":      bidCount,
+		"confirmed":      false,
+	}
+
+	// 合约要求出价递增，最后一笔出价即最高出价
+	var latest models.Bid
+	err := tx.Scopes(scope).Order("block_number DESC, id DESC").First(&latest).Error
+	if err == nil {
+		updates["highest_bidder"] = latest.Bidder
+		updates["highest_bid"] = latest.Amount
+		updates["token_address"] = latest.TokenAddress
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to find latest bid: %w", err)
+	}
+
+	if err := tx.Model(&models.Auction{}).Scopes(scope).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to recompute auction %d: %w", auctionID, err)
+	}
+	return nil
+}
+
+// pruneBlocks 清理超出重组检测范围的区块哈希记录
+func (el *EventListener) pruneBlocks(head uint64) error {
+	if head <= config.AppConfig.ReorgDepth {
+		return nil
+	}
+	return database.GetDB().
+		Where("chain_id = ? AND contract_address = ? AND block_number < ?",
+			el.chainID, el.contractKey(), head-config.AppConfig.ReorgDepth).
+		Delete(&models.IndexedBlock{}).Error
+}
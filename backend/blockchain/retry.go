@@ -0,0 +1,110 @@
+package blockchain
+
+import (
+	"auction-backend/database"
+	"auction-backend/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// maxApplyAttempts 单条日志的最大处理次数
+	maxApplyAttempts = 3
+	// applyRetryBackoff 首次重试前的等待时间，之后逐次翻倍
+	applyRetryBackoff = 500 * time.Millisecond
+)
+
+// errDecode 日志解码失败，重试无法恢复
+var errDecode = errors.New("decode failed")
+
+// isPermanentError 判断错误是否重试也无法恢复（解码失败、依赖的拍卖不存在等）
+func isPermanentError(err error) bool {
+	return errors.Is(err, errDecode) || errors.Is(err, gorm.ErrRecordNotFound)
+}
+
+// parkLog 将无法处理的日志写入死信表并推进游标，避免阻塞后续日志
+func (el *EventListener) parkLog(vLog types.Log, eventName string, cause error) error {
+	log.Printf("Dead-lettering log %s#%d (%s): %v\n", vLog.TxHash.Hex(), vLog.Index, eventName, cause)
+
+	rawLog, err := json.Marshal(vLog)
+	if err != nil {
+		return fmt.Errorf("failed to encode log: %w", err)
+	}
+
+	return el.park(models.DeadLetter{
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		TxHash:          vLog.TxHash.Hex(),
+		LogIndex:        vLog.Index,
+		BlockNumber:     vLog.BlockNumber,
+		Source:          models.DeadLetterSourceLog,
+		EventName:       eventName,
+		RawLog:          string(rawLog),
+		Error:           cause.Error(),
+		Attempts:        1,
+		Status:          models.DeadLetterPending,
+	}, vLog.BlockHash)
+}
+
+// parkCall 将调用解码模式下无法处理的交易调用写入死信表并推进游标，与日志共用死信的查看和重试
+func (el *EventListener) parkCall(call *callRecord, cause error) error {
+	log.Printf("Dead-lettering call %s %s: %v\n", call.method.Name, call.tx.Hash().Hex(), cause)
+
+	rawTx, err := call.tx.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	return el.park(models.DeadLetter{
+		ChainID:         el.chainID,
+		ContractAddress: el.contractKey(),
+		TxHash:          call.tx.Hash().Hex(),
+		LogIndex:        call.index,
+		BlockNumber:     call.block.NumberU64(),
+		Source:          models.DeadLetterSourceCall,
+		EventName:       call.method.Name,
+		RawLog:          string(rawTx),
+		Error:           cause.Error(),
+		Attempts:        1,
+		Status:          models.DeadLetterPending,
+	}, call.block.Hash())
+}
+
+// park 写入死信并在同一事务中推进游标
+func (el *EventListener) park(letter models.DeadLetter, blockHash common.Hash) error {
+	err := database.GetDB().Transaction(func(tx *gorm.DB) error {
+		// 同一日志再次失败时（如重组后重新同步）更新失败原因并重新置为待处理
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: identityColumns,
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"error":    letter.Error,
+				"raw_log":  letter.RawLog,
+				"status":   models.DeadLetterPending,
+				"attempts": gorm.Expr("attempts + 1"),
+			}),
+		}).Create(&letter).Error; err != nil {
+			return fmt.Errorf("failed to save dead letter: %w", err)
+		}
+		if err := el.recordBlock(tx, letter.BlockNumber, blockHash); err != nil {
+			return err
+		}
+		return el.saveCursor(tx, letter.BlockNumber, int(letter.LogIndex))
+	})
+	if err != nil {
+		return err
+	}
+	el.advanceCursor(letter.BlockNumber, int(letter.LogIndex))
+
+	el.progressMu.Lock()
+	el.progress.ParkedLogs++
+	el.progressMu.Unlock()
+	return nil
+}
@@ -0,0 +1,684 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	rpcProbeTimeout = 5 * time.Second
+	// rpcEWMAWeight 延迟和错误率指数移动平均中新样本的权重
+	rpcEWMAWeight = 0.2
+	// rpcErrorPenalty 评分中错误率的权重，错误率每增加 25% 相当于延迟翻倍
+	rpcErrorPenalty = 4
+	// rpcMaxConsecutiveFailures 连续失败达到该次数的节点视为不健康，直到再次成功
+	rpcMaxConsecutiveFailures = 3
+	// rpcLimitExceededCode 节点限流时返回的 JSON-RPC 错误码
+	rpcLimitExceededCode = -32005
+)
+
+// ErrNoRPCEndpoint 节点池中没有可用的节点
+var ErrNoRPCEndpoint = errors.New("no usable RPC endpoint")
+
+// pools 各链的 RPC 节点池，由 InitRPCPools 建立
+var (
+	poolsMu sync.RWMutex
+	pools   = make(map[uint64]*RPCPool)
+)
+
+// RPCPool 同一条链上多个 RPC 节点组成的节点池，监听器和合约服务共享使用
+// 每次调用按健康状况和评分选择节点，节点故障时自动切换到下一个；实现 bind.ContractBackend，可直接用于合约绑定
+type RPCPool struct {
+	chainID   uint64
+	endpoints []*rpcEndpoint // 按配置顺序
+}
+
+// rpcEndpoint 节点池中的单个节点及其健康统计
+type rpcEndpoint struct {
+	url  string
+	name string // 只含协议和主机的地址，路径中常带有 API key，不出现在状态和日志中
+
+	mu          sync.Mutex
+	client      *ethclient.Client
+	disabled    bool          // 节点所在链与节点池不一致，不再使用
+	latency     time.Duration // 成功请求延迟的指数移动平均
+	errorRate   float64       // 请求失败率的指数移动平均
+	consecutive int           // 连续失败次数
+	head        uint64        // 最近一次探测到的最新区块
+	headLag     uint64        // 落后同链最高节点的区块数
+	requests    uint64
+	failures    uint64
+	lastError   string
+	lastErrorAt *time.Time
+}
+
+// EndpointStatus RPC 节点状态
+type EndpointStatus struct {
+	Endpoint    string     `json:"endpoint"` // 只含协议和主机
+	Healthy     bool       `json:"healthy"`
+	Disabled    bool       `json:"disabled,omitempty"`
+	LatencyMs   int64      `json:"latency_ms"`
+	ErrorRate   float64    `json:"error_rate"`
+	Head        uint64     `json:"head"`
+	HeadLag     uint64     `json:"head_lag"`
+	Requests    uint64     `json:"requests"`
+	Failures    uint64     `json:"failures"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// endpointError 节点故障，错误信息中的节点地址已替换为不含 API key 的名称
+type endpointError struct {
+	endpoint *rpcEndpoint
+	err      error
+}
+
+func (e *endpointError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.endpoint.url, e.endpoint.name)
+}
+
+func (e *endpointError) Unwrap() error {
+	return e.err
+}
+
+// InitRPCPools 根据 ETH_RPC_URL 确定默认链，并为默认链和 CHAIN_RPC_URLS 中的每条链建立节点池
+// 节点池在 ctx 取消前定期探测各节点
+func InitRPCPools(ctx context.Context) error {
+	defaultURLs := splitURLs(config.AppConfig.ETHRPCURL)
+	chainID, err := detectChainID(ctx, defaultURLs)
+	if err != nil {
+		return err
+	}
+	defaultChainID = chainID
+
+	groups := map[uint64][]string{chainID: defaultURLs}
+	for id, urls := range config.AppConfig.ChainRPCURLs {
+		groups[id] = append(groups[id], urls...)
+	}
+
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+	for id, urls := range groups {
+		pool := newRPCPool(id, urls)
+		pool.probe(ctx)
+		pools[id] = pool
+		go pool.Run(ctx)
+		log.Printf("RPC pool for chain %d: %d endpoint(s), %d healthy\n", id, len(pool.endpoints), pool.healthyCount())
+	}
+	return nil
+}
+
+// detectChainID 依次询问节点的链ID，以第一个应答的节点为准
+func detectChainID(ctx context.Context, urls []string) (uint64, error) {
+	var lastErr error
+	for _, rawURL := range urls {
+		ep := &rpcEndpoint{url: rawURL, name: endpointName(rawURL)}
+		dialCtx, cancel := context.WithTimeout(ctx, rpcProbeTimeout)
+		client, err := ethclient.DialContext(dialCtx, rawURL)
+		if err == nil {
+			var chainID *big.Int
+			chainID, err = client.ChainID(dialCtx)
+			client.Close()
+			if err == nil {
+				cancel()
+				return chainID.Uint64(), nil
+			}
+		}
+		cancel()
+		lastErr = &endpointError{endpoint: ep, err: err}
+		log.Printf("Failed to get chain ID from %s: %v\n", ep.name, lastErr)
+	}
+	if lastErr == nil {
+		return 0, fmt.Errorf("%w: ETH_RPC_URL is empty", ErrNoRPCEndpoint)
+	}
+	return 0, fmt.Errorf("failed to get chain ID: %w", lastErr)
+}
+
+// splitURLs 拆分逗号分隔的地址列表
+func splitURLs(value string) []string {
+	var urls []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			urls = append(urls, entry)
+		}
+	}
+	return urls
+}
+
+// endpointName 节点在状态和日志中显示的名称，只保留协议和主机
+func endpointName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		// IPC 路径不含密钥
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// newRPCPool 创建节点池，重复的地址只保留一个，连接在探测或首次调用时建立
+func newRPCPool(chainID uint64, urls []string) *RPCPool {
+	pool := &RPCPool{chainID: chainID}
+	seen := make(map[string]bool)
+	for _, rawURL := range urls {
+		if seen[rawURL] {
+			continue
+		}
+		seen[rawURL] = true
+		pool.endpoints = append(pool.endpoints, &rpcEndpoint{url: rawURL, name: endpointName(rawURL)})
+	}
+	return pool
+}
+
+// GetRPCPool 获取链的节点池，链未配置 RPC 地址时返回 ErrUnknownChain
+func GetRPCPool(chainID uint64) (*RPCPool, error) {
+	poolsMu.RLock()
+	defer poolsMu.RUnlock()
+	pool, ok := pools[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownChain, chainID)
+	}
+	return pool, nil
+}
+
+// RPCPools 获取所有节点池，按链ID索引
+func RPCPools() map[uint64]*RPCPool {
+	poolsMu.RLock()
+	defer poolsMu.RUnlock()
+
+	result := make(map[uint64]*RPCPool, len(pools))
+	for chainID, pool := range pools {
+		result[chainID] = pool
+	}
+	return result
+}
+
+// Run 定期探测节点的延迟和最新区块，直到 ctx 取消
+func (p *RPCPool) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(config.AppConfig.RPCProbeInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.probe(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// probe 并发读取各节点的最新区块，以同链最高的区块计算各节点落后的区块数
+func (p *RPCPool) probe(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, ep := range p.endpoints {
+		if ep.isDisabled() {
+			continue
+		}
+		wg.Add(1)
+		go func(ep *rpcEndpoint) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, rpcProbeTimeout)
+			defer cancel()
+
+			client, err := ep.connect(probeCtx, p.chainID)
+			if err != nil {
+				return
+			}
+			start := time.Now()
+			head, err := client.BlockNumber(probeCtx)
+			ep.record(time.Since(start), err)
+			if err == nil {
+				ep.mu.Lock()
+				ep.head = head
+				ep.mu.Unlock()
+			}
+		}(ep)
+	}
+	wg.Wait()
+
+	var maxHead uint64
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		maxHead = max(maxHead, ep.head)
+		ep.mu.Unlock()
+	}
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		ep.headLag = maxHead - ep.head
+		ep.mu.Unlock()
+	}
+}
+
+// Status 返回各节点的健康状况，顺序与配置一致
+func (p *RPCPool) Status() []EndpointStatus {
+	statuses := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		statuses = append(statuses, EndpointStatus{
+			Endpoint:    ep.name,
+			Healthy:     ep.healthyLocked(),
+			Disabled:    ep.disabled,
+			LatencyMs:   ep.latency.Milliseconds(),
+			ErrorRate:   ep.errorRate,
+			Head:        ep.head,
+			HeadLag:     ep.headLag,
+			Requests:    ep.requests,
+			Failures:    ep.failures,
+			LastError:   ep.lastError,
+			LastErrorAt: ep.lastErrorAt,
+		})
+		ep.mu.Unlock()
+	}
+	return statuses
+}
+
+// Healthy 节点池中是否还有健康的节点
+func (p *RPCPool) Healthy() bool {
+	return p.healthyCount() > 0
+}
+
+// healthyCount 健康节点的数量
+func (p *RPCPool) healthyCount() int {
+	count := 0
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		if ep.healthyLocked() {
+			count++
+		}
+		ep.mu.Unlock()
+	}
+	return count
+}
+
+// ordered 按调用优先级排列节点：健康的节点在前，同组内评分低（延迟低、错误少）的在前
+// 不健康的节点仍排在最后参与重试，避免所有节点同时被判为不健康时请求直接失败
+func (p *RPCPool) ordered() []*rpcEndpoint {
+	type candidate struct {
+		endpoint *rpcEndpoint
+		healthy  bool
+		cost     float64
+	}
+
+	candidates := make([]candidate, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		if !ep.disabled {
+			candidates = append(candidates, candidate{
+				endpoint: ep,
+				healthy:  ep.healthyLocked(),
+				cost:     float64(ep.latency) * (1 + rpcErrorPenalty*ep.errorRate),
+			})
+		}
+		ep.mu.Unlock()
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].healthy != candidates[j].healthy {
+			return candidates[i].healthy
+		}
+		return candidates[i].cost < candidates[j].cost
+	})
+
+	endpoints := make([]*rpcEndpoint, len(candidates))
+	for i, c := range candidates {
+		endpoints[i] = c.endpoint
+	}
+	return endpoints
+}
+
+// call 按优先级依次在节点上执行 fn，节点故障时切换到下一个节点
+// 节点正常返回的错误（如 revert、nonce 过低、记录不存在）直接返回，不切换节点
+func (p *RPCPool) call(ctx context.Context, fn func(*ethclient.Client) error) error {
+	var lastErr error
+	for _, ep := range p.ordered() {
+		client, err := ep.connect(ctx, p.chainID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		start := time.Now()
+		err = fn(client)
+		if !isEndpointError(ctx, err) {
+			ep.record(time.Since(start), nil)
+			return err
+		}
+		ep.record(time.Since(start), err)
+		lastErr = &endpointError{endpoint: ep, err: err}
+		log.Printf("RPC endpoint %s on chain %d failed: %v\n", ep.name, p.chainID, lastErr)
+	}
+
+	if lastErr == nil {
+		return fmt.Errorf("%w on chain %d", ErrNoRPCEndpoint, p.chainID)
+	}
+	return fmt.Errorf("all RPC endpoints on chain %d failed: %w", p.chainID, lastErr)
+}
+
+// isEndpointError 判断错误是否由节点故障引起（连接失败、超时、HTTP 错误、限流），这类错误换节点重试
+func isEndpointError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == rpcLimitExceededCode
+	}
+	return true
+}
+
+// connect 返回节点的客户端，尚未连接时建立连接并校验节点所在的链
+func (ep *rpcEndpoint) connect(ctx context.Context, chainID uint64) (*ethclient.Client, error) {
+	ep.mu.Lock()
+	client := ep.client
+	ep.mu.Unlock()
+	if client != nil {
+		return client, nil
+	}
+
+	client, err := ethclient.DialContext(ctx, ep.url)
+	if err == nil {
+		var actual *big.Int
+		if actual, err = client.ChainID(ctx); err != nil {
+			client.Close()
+		} else if actual.Uint64() != chainID {
+			client.Close()
+			err = fmt.Errorf("endpoint is on chain %d, expected %d", actual.Uint64(), chainID)
+			ep.disable(err)
+			log.Printf("Disabled RPC endpoint %s: %v\n", ep.name, err)
+			return nil, &endpointError{endpoint: ep, err: err}
+		}
+	}
+	if err != nil {
+		ep.record(0, err)
+		return nil, &endpointError{endpoint: ep, err: fmt.Errorf("failed to connect to ethereum client: %w", err)}
+	}
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	if ep.client != nil {
+		// 并发调用已建立连接
+		client.Close()
+		return ep.client, nil
+	}
+	ep.client = client
+	return client, nil
+}
+
+// record 记录一次请求结果，更新延迟、错误率和连续失败次数
+func (ep *rpcEndpoint) record(latency time.Duration, err error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	ep.requests++
+	if err == nil {
+		ep.consecutive = 0
+		ep.errorRate *= 1 - rpcEWMAWeight
+		if ep.latency == 0 {
+			ep.latency = latency
+		} else {
+			ep.latency = time.Duration(float64(ep.latency)*(1-rpcEWMAWeight) + float64(latency)*rpcEWMAWeight)
+		}
+		return
+	}
+
+	now := time.Now()
+	ep.failures++
+	ep.consecutive++
+	ep.errorRate = ep.errorRate*(1-rpcEWMAWeight) + rpcEWMAWeight
+	ep.lastError = strings.ReplaceAll(err.Error(), ep.url, ep.name)
+	ep.lastErrorAt = &now
+}
+
+// disable 停用节点所在链与节点池不一致的节点
+func (ep *rpcEndpoint) disable(err error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	now := time.Now()
+	ep.disabled = true
+	ep.lastError = err.Error()
+	ep.lastErrorAt = &now
+}
+
+// isDisabled 节点是否已停用
+func (ep *rpcEndpoint) isDisabled() bool {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	return ep.disabled
+}
+
+// healthyLocked 节点是否健康：已连接、未连续失败、最新区块未明显落后，调用方需持有 ep.mu
+func (ep *rpcEndpoint) healthyLocked() bool {
+	return !ep.disabled && ep.client != nil &&
+		ep.consecutive < rpcMaxConsecutiveFailures &&
+		ep.headLag <= config.AppConfig.RPCMaxHeadLag
+}
+
+// ChainID 节点池所在链的ID，连接节点时已校验，不发起请求
+func (p *RPCPool) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).SetUint64(p.chainID), nil
+}
+
+// BlockNumber 获取最新区块号
+func (p *RPCPool) BlockNumber(ctx context.Context) (uint64, error) {
+	var number uint64
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		number, err = client.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+// BlockByNumber 获取区块，number 为 nil 时返回最新区块
+func (p *RPCPool) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var block *types.Block
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		block, err = client.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+// HeaderByNumber 获取区块头，number 为 nil 时返回最新区块头
+func (p *RPCPool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		header, err = client.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// HeaderByHash 按哈希获取区块头
+func (p *RPCPool) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var header *types.Header
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		header, err = client.HeaderByHash(ctx, hash)
+		return err
+	})
+	return header, err
+}
+
+// TransactionByHash 按哈希获取交易
+func (p *RPCPool) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var (
+		tx        *types.Transaction
+		isPending bool
+	)
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		tx, isPending, err = client.TransactionByHash(ctx, hash)
+		return err
+	})
+	return tx, isPending, err
+}
+
+// TransactionReceipt 获取交易回执
+func (p *RPCPool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+// NonceAt 获取账户在指定区块的 nonce
+func (p *RPCPool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var nonce uint64
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		nonce, err = client.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+// PendingNonceAt 获取账户包括交易池在内的下一个 nonce
+func (p *RPCPool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce uint64
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		nonce, err = client.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// CodeAt 获取合约代码
+func (p *RPCPool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		code, err = client.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// PendingCodeAt 获取 pending 状态下的合约代码
+func (p *RPCPool) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var code []byte
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		code, err = client.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// StorageAt 读取合约存储槽
+func (p *RPCPool) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var value []byte
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		value, err = client.StorageAt(ctx, account, key, blockNumber)
+		return err
+	})
+	return value, err
+}
+
+// CallContract 执行只读合约调用
+func (p *RPCPool) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		result, err = client.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// EstimateGas 估算交易的 gas
+func (p *RPCPool) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		gas, err = client.EstimateGas(ctx, msg)
+		return err
+	})
+	return gas, err
+}
+
+// SuggestGasPrice 获取建议的 gas 价格
+func (p *RPCPool) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var price *big.Int
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		price, err = client.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+// SuggestGasTipCap 获取建议的 EIP-1559 小费
+func (p *RPCPool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tip *big.Int
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		tip, err = client.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+// SendTransaction 广播交易
+// 前一个节点故障后换节点重发时，交易可能已被前一个节点接收，此时 already known 视为成功
+func (p *RPCPool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	failedOver := false
+	return p.call(ctx, func(client *ethclient.Client) error {
+		err := client.SendTransaction(ctx, tx)
+		if err != nil && failedOver && isAlreadyKnown(err) {
+			return nil
+		}
+		failedOver = true
+		return err
+	})
+}
+
+// FilterLogs 查询日志
+func (p *RPCPool) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := p.call(ctx, func(client *ethclient.Client) (err error) {
+		logs, err = client.FilterLogs(ctx, q)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs 在第一个支持订阅的节点上订阅日志
+// 订阅固定在该节点上，断开后由调用方重新订阅，届时会选择当前最优的节点
+func (p *RPCPool) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var lastErr error
+	for _, ep := range p.ordered() {
+		client, err := ep.connect(ctx, p.chainID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		sub, err := client.SubscribeFilterLogs(ctx, q, ch)
+		if err == nil {
+			return sub, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		// HTTP 节点不支持订阅属于正常情况，不计入节点故障
+		if isEndpointError(ctx, err) {
+			ep.record(0, err)
+		}
+		lastErr = &endpointError{endpoint: ep, err: err}
+	}
+
+	if lastErr == nil {
+		return nil, fmt.Errorf("%w on chain %d", ErrNoRPCEndpoint, p.chainID)
+	}
+	return nil, lastErr
+}
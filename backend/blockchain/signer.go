@@ -0,0 +1,245 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrNoSigner 未配置服务端签名账户
+var ErrNoSigner = errors.New("operator signer is not configured")
+
+// operatorSigner 全局服务端签名账户，供管理操作和自动结束拍卖使用
+var operatorSigner Signer
+
+// Signer 服务端交易签名
+type Signer interface {
+	// Address 签名账户地址
+	Address() common.Address
+	// SignTx 对交易签名
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// SetOperatorSigner 设置全局服务端签名账户
+func SetOperatorSigner(s Signer) {
+	operatorSigner = s
+}
+
+// GetOperatorSigner 获取全局服务端签名账户，未配置时返回 nil
+func GetOperatorSigner() Signer {
+	return operatorSigner
+}
+
+// NewSignerFromConfig 根据 SIGNER_TYPE 创建签名账户，未配置时返回 nil
+func NewSignerFromConfig() (Signer, error) {
+	cfg := config.AppConfig
+	switch cfg.SignerType {
+	case "", "none":
+		return nil, nil
+	case "keystore":
+		if cfg.SignerKeystorePath == "" {
+			return nil, fmt.Errorf("SIGNER_KEYSTORE_PATH is required for keystore signer")
+		}
+		return NewKeystoreSigner(cfg.SignerKeystorePath, cfg.SignerKeystorePassword)
+	case "remote":
+		if cfg.SignerRemoteURL == "" || !common.IsHexAddress(cfg.SignerAddress) {
+			return nil, fmt.Errorf("SIGNER_REMOTE_URL and SIGNER_ADDRESS are required for remote signer")
+		}
+		backend := NewHTTPSigningBackend(cfg.SignerRemoteURL, cfg.SignerRemoteKeyID, cfg.SignerRemoteToken)
+		return NewRemoteSigner(common.HexToAddress(cfg.SignerAddress), backend), nil
+	case "local":
+		// 本地替身，使用明文私钥模拟远程签名服务，仅用于开发和测试
+		backend, err := NewLocalSigningBackend(cfg.SignerLocalKey)
+		if err != nil {
+			return nil, err
+		}
+		return NewRemoteSigner(backend.Address(), backend), nil
+	}
+	return nil, fmt.Errorf("unknown signer type %q", cfg.SignerType)
+}
+
+// KeystoreSigner 使用 go-ethereum 加密 keystore 文件签名
+type KeystoreSigner struct {
+	key *keystore.Key
+}
+
+// NewKeystoreSigner 读取并解密 keystore 文件
+func NewKeystoreSigner(path, password string) (*KeystoreSigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+	return &KeystoreSigner{key: key}, nil
+}
+
+// Address 签名账户地址
+func (s *KeystoreSigner) Address() common.Address {
+	return s.key.Address
+}
+
+// SignTx 使用解密后的私钥签名
+func (s *KeystoreSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key.PrivateKey)
+}
+
+// SigningBackend 远程签名服务（如云 KMS），私钥不离开服务，只对交易摘要签名
+type SigningBackend interface {
+	// SignDigest 对 32 字节摘要签名，返回 65 字节 [R || S || V] 格式签名，V 为 0 或 1
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// RemoteSigner 通过 SigningBackend 签名
+type RemoteSigner struct {
+	address common.Address
+	backend SigningBackend
+}
+
+// NewRemoteSigner 创建远程签名账户
+func NewRemoteSigner(address common.Address, backend SigningBackend) *RemoteSigner {
+	return &RemoteSigner{address: address, backend: backend}
+}
+
+// Address 签名账户地址
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx 计算交易摘要交由远程服务签名，并校验签名确实来自该账户
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	digest := signer.Hash(tx)
+
+	sig, err := s.backend.SignDigest(ctx, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("remote signing failed: %w", err)
+	}
+
+	signed, err := tx.WithSignature(signer, sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %w", err)
+	}
+
+	from, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %w", err)
+	}
+	if from != s.address {
+		return nil, fmt.Errorf("remote signature is from %s, expected %s", from.Hex(), s.address.Hex())
+	}
+	return signed, nil
+}
+
+// HTTPSigningBackend 通过 HTTP 调用远程签名服务
+// 请求 POST {url} {"key_id": "...", "digest": "0x..."}，响应 {"signature": "0x..."}
+type HTTPSigningBackend struct {
+	url    string
+	keyID  string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPSigningBackend 创建 HTTP 远程签名服务客户端
+func NewHTTPSigningBackend(url, keyID, token string) *HTTPSigningBackend {
+	return &HTTPSigningBackend{
+		url:    url,
+		keyID:  keyID,
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SignDigest 请求远程服务签名
+func (b *HTTPSigningBackend) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{
+		"key_id": b.keyID,
+		"digest": hexutil.Encode(digest),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call signing service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signing service returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result struct {
+		Signature string `json:"signature"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	sig, err := hexutil.Decode(result.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	// 部分服务返回 27/28 形式的 V
+	if len(sig) == crypto.SignatureLength && sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	return sig, nil
+}
+
+// LocalSigningBackend 本地私钥实现的签名服务替身
+type LocalSigningBackend struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewLocalSigningBackend 从 hex 私钥创建本地签名服务替身
+func NewLocalSigningBackend(privateKeyHex string) (*LocalSigningBackend, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return &LocalSigningBackend{key: key}, nil
+}
+
+// Address 私钥对应的地址
+func (b *LocalSigningBackend) Address() common.Address {
+	return crypto.PubkeyToAddress(b.key.PublicKey)
+}
+
+// SignDigest 使用本地私钥签名
+func (b *LocalSigningBackend) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	return crypto.Sign(digest, b.key)
+}
@@ -0,0 +1,98 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// gasLimitMarginPercent 在预估 gas 上预留的余量（百分比），避免出价期间状态变化导致 gas 不足
+const gasLimitMarginPercent = 120
+
+// RevertError 交易模拟执行被合约拒绝
+type RevertError struct {
+	Method string `json:"method"`         // 调用的合约方法
+	Reason string `json:"reason"`         // 解码后的拒绝原因，例如 "Auction has ended"
+	Data   string `json:"data,omitempty"` // 原始的 revert 数据（hex）
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("%s reverted: %s", e.Method, e.Reason)
+}
+
+// preflight 先用 eth_call 模拟执行，再预估 gas，模拟失败时返回 *RevertError
+func (cs *ContractService) preflight(ctx context.Context, method string, msg ethereum.CallMsg) (uint64, error) {
+	if err := cs.simulate(ctx, method, msg); err != nil {
+		return 0, err
+	}
+
+	gas, err := cs.client.EstimateGas(ctx, msg)
+	if err != nil {
+		// 模拟与预估之间状态可能已变化，预估同样可能因 revert 失败
+		return 0, cs.decodeRevert(method, err)
+	}
+	return gas * gasLimitMarginPercent / 100, nil
+}
+
+// simulate 在最新状态上用 eth_call 模拟执行交易
+func (cs *ContractService) simulate(ctx context.Context, method string, msg ethereum.CallMsg) error {
+	if _, err := cs.client.CallContract(ctx, msg, nil); err != nil {
+		return cs.decodeRevert(method, err)
+	}
+	return nil
+}
+
+// decodeRevert 从 RPC 错误中解析 revert 原因，非 revert 错误原样返回
+func (cs *ContractService) decodeRevert(method string, err error) error {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if encoded, ok := dataErr.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(encoded); decodeErr == nil && len(data) > 0 {
+				return &RevertError{
+					Method: method,
+					Reason: cs.revertReason(data, err),
+					Data:   encoded,
+				}
+			}
+		}
+	}
+
+	// 部分节点不返回 revert 数据，只在错误信息中带上原因
+	msg := err.Error()
+	if strings.Contains(msg, "execution reverted") {
+		reason := strings.TrimSpace(strings.TrimPrefix(msg[strings.Index(msg, "execution reverted"):], "execution reverted"))
+		reason = strings.TrimSpace(strings.TrimPrefix(reason, ":"))
+		if reason == "" {
+			reason = "execution reverted"
+		}
+		return &RevertError{Method: method, Reason: reason}
+	}
+	return fmt.Errorf("failed to simulate %s: %w", method, err)
+}
+
+// revertReason 依次尝试 Error(string)、Panic(uint256) 和合约 ABI 中定义的自定义错误
+func (cs *ContractService) revertReason(data []byte, err error) string {
+	if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+		return reason
+	}
+	if len(data) >= 4 {
+		for name, customErr := range cs.contractABI.Errors {
+			if !bytes.Equal(customErr.ID[:4], data[:4]) {
+				continue
+			}
+			args, unpackErr := customErr.Unpack(data)
+			if unpackErr != nil {
+				return name
+			}
+			return fmt.Sprintf("%s%v", name, args)
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,156 @@
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testDataError 模拟节点返回的带 revert 数据的 RPC 错误
+type testDataError struct {
+	msg  string
+	data interface{}
+}
+
+func (e *testDataError) Error() string          { return e.msg }
+func (e *testDataError) ErrorCode() int         { return 3 }
+func (e *testDataError) ErrorData() interface{} { return e.data }
+
+// revertData 按 selector 和参数编码 revert 数据
+func revertData(t *testing.T, selector []byte, typ string, value interface{}) string {
+	t.Helper()
+	data := append([]byte{}, selector...)
+	if typ != "" {
+		argType, err := abi.NewType(typ, "", nil)
+		if err != nil {
+			t.Fatalf("failed to create abi type: %v", err)
+		}
+		packed, err := abi.Arguments{{Type: argType}}.Pack(value)
+		if err != nil {
+			t.Fatalf("failed to pack revert data: %v", err)
+		}
+		data = append(data, packed...)
+	}
+	return hexutil.Encode(data)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	contractABI, err := NftAuctionMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("failed to parse abi: %v", err)
+	}
+	cs := &ContractService{contractABI: *contractABI}
+
+	implementation := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	errorString := revertData(t, hexutil.MustDecode("0x08c379a0"), "string", "Auction has ended")
+	overflow := revertData(t, hexutil.MustDecode("0x4e487b71"), "uint256", big.NewInt(0x11))
+	unknownPanic := revertData(t, hexutil.MustDecode("0x4e487b71"), "uint256", big.NewInt(0x99))
+	failedCall := revertData(t, contractABI.Errors["FailedCall"].ID.Bytes()[:4], "", nil)
+	invalidImpl := revertData(t, contractABI.Errors["ERC1967InvalidImplementation"].ID.Bytes()[:4], "address", implementation)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantRevert bool
+		wantReason string
+		wantData   string
+	}{
+		{
+			name:       "Error(string)",
+			err:        &testDataError{msg: "execution reverted", data: errorString},
+			wantRevert: true,
+			wantReason: "Auction has ended",
+			wantData:   errorString,
+		},
+		{
+			name:       "Panic(uint256) arithmetic overflow",
+			err:        &testDataError{msg: "execution reverted", data: overflow},
+			wantRevert: true,
+			wantReason: "arithmetic underflow or overflow",
+			wantData:   overflow,
+		},
+		{
+			name:       "Panic(uint256) unknown code",
+			err:        &testDataError{msg: "execution reverted", data: unknownPanic},
+			wantRevert: true,
+			wantReason: "unknown panic code: 0x99",
+			wantData:   unknownPanic,
+		},
+		{
+			name:       "custom error without arguments",
+			err:        &testDataError{msg: "execution reverted", data: failedCall},
+			wantRevert: true,
+			wantReason: "FailedCall[]",
+			wantData:   failedCall,
+		},
+		{
+			name:       "custom error with arguments",
+			err:        &testDataError{msg: "execution reverted", data: invalidImpl},
+			wantRevert: true,
+			wantReason: "ERC1967InvalidImplementation[" + implementation.Hex() + "]",
+			wantData:   invalidImpl,
+		},
+		{
+			name:       "unknown selector keeps node message",
+			err:        &testDataError{msg: "execution reverted: 0xdeadbeef", data: "0xdeadbeef"},
+			wantRevert: true,
+			wantReason: "execution reverted: 0xdeadbeef",
+			wantData:   "0xdeadbeef",
+		},
+		{
+			name:       "empty revert data falls back to message",
+			err:        &testDataError{msg: "execution reverted: Not the seller", data: "0x"},
+			wantRevert: true,
+			wantReason: "Not the seller",
+		},
+		{
+			name:       "reason only in message",
+			err:        errors.New("execution reverted: Auction has ended"),
+			wantRevert: true,
+			wantReason: "Auction has ended",
+		},
+		{
+			name:       "bare execution reverted",
+			err:        errors.New("execution reverted"),
+			wantRevert: true,
+			wantReason: "execution reverted",
+		},
+		{
+			name: "non-revert error",
+			err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cs.decodeRevert("bid", tt.err)
+
+			var revertErr *RevertError
+			if !tt.wantRevert {
+				if errors.As(got, &revertErr) {
+					t.Fatalf("decodeRevert() = %v, want non-revert error", got)
+				}
+				if !errors.Is(got, tt.err) {
+					t.Fatalf("decodeRevert() = %v, want wrapped %v", got, tt.err)
+				}
+				return
+			}
+			if !errors.As(got, &revertErr) {
+				t.Fatalf("decodeRevert() = %v, want *RevertError", got)
+			}
+			if revertErr.Method != "bid" {
+				t.Errorf("Method = %q, want %q", revertErr.Method, "bid")
+			}
+			if revertErr.Reason != tt.wantReason {
+				t.Errorf("Reason = %q, want %q", revertErr.Reason, tt.wantReason)
+			}
+			if revertErr.Data != tt.wantData {
+				t.Errorf("Data = %q, want %q", revertErr.Data, tt.wantData)
+			}
+		})
+	}
+}
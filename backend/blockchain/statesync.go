@@ -0,0 +1,157 @@
+package blockchain
+
+import (
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+// auctionState 合约 auctions(i) 的返回值，与生成的绑定类型一致
+type auctionState = struct {
+	Seller        common.Address
+	StartPrice    *big.Int
+	StartTime     *big.Int
+	Duration      *big.Int
+	Isend         bool
+	HighestBidder common.Address
+	HightestPrice *big.Int
+	NftContract   common.Address
+	TokenId       *big.Int
+	TokenAddress  common.Address
+}
+
+// syncState 读取合约中 0..nextAuctionId-1 的拍卖状态，补齐或校正本地记录
+// 用于覆盖调用解码无法看到的情况，例如通过其他合约间接调用
+func (el *EventListener) syncState(ctx context.Context) error {
+	head, err := el.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	caller, err := NewNftAuctionCaller(el.contractAddress, el.client)
+	if err != nil {
+		return fmt.Errorf("failed to bind contract: %w", err)
+	}
+	// 所有读取固定在同一区块，保证快照一致
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(head)}
+
+	next, err := caller.NextAuctionId(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get next auction ID: %w", err)
+	}
+
+	updated := 0
+	for id := uint64(0); id < next.Uint64(); id++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		onchain, err := caller.Auctions(opts, new(big.Int).SetUint64(id))
+		if err != nil {
+			return fmt.Errorf("failed to get auction %d: %w", id, err)
+		}
+
+		changed, err := syncAuctionState(el.chainID, el.contractKey(), uint(id), onchain, head)
+		if err != nil {
+			return err
+		}
+		if changed {
+			updated++
+		}
+	}
+
+	if updated > 0 {
+		log.Printf("State sync updated %d of %d auctions at block %d\n", updated, next.Uint64(), head)
+	}
+	return nil
+}
+
+// syncAuctionState 将单个拍卖的链上状态写入本地，返回是否有改动
+func syncAuctionState(chainID uint64, contract string, auctionID uint, onchain auctionState, head uint64) (bool, error) {
+	state := models.Auction{
+		ChainID:         chainID,
+		ContractAddress: contract,
+		AuctionID:       auctionID,
+		Seller:          strings.ToLower(onchain.Seller.Hex()),
+		NFTContract:     strings.ToLower(onchain.NftContract.Hex()),
+		TokenID:         onchain.TokenId.String(),
+		StartPrice:      onchain.StartPrice.String(),
+		Duration:        onchain.Duration.Uint64(),
+		StartTime:       onchain.StartTime.Uint64(),
+		Ended:           onchain.Isend,
+		TokenAddress:    strings.ToLower(onchain.TokenAddress.Hex()),
+	}
+	// 尚无出价时合约中的最高出价者为零地址
+	if onchain.HighestBidder != (common.Address{}) {
+		state.HighestBidder = strings.ToLower(onchain.HighestBidder.Hex())
+		state.HighestBid = onchain.HightestPrice.String()
+	}
+
+	db := database.GetDB()
+	var auction models.Auction
+	scope := chainContractScope(chainID, contract)
+	err := db.Scopes(scope).Where("auction_id = ?", auctionID).First(&auction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 创建区块未知，以本次读取的区块近似
+		state.CreatedBlock = head
+		if state.Ended {
+			state.EndedBlock = &head
+		}
+		if err := db.Create(&state).Error; err != nil {
+			return false, fmt.Errorf("failed to save auction %d: %w", auctionID, err)
+		}
+		log.Printf("State sync found missing auction %d\n", auctionID)
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find auction %d: %w", auctionID, err)
+	}
+
+	if auction.Seller == state.Seller &&
+		auction.NFTContract == state.NFTContract &&
+		auction.TokenID == state.TokenID &&
+		auction.StartPrice == state.StartPrice &&
+		auction.Duration == state.Duration &&
+		auction.StartTime == state.StartTime &&
+		auction.Ended == state.Ended &&
+		auction.HighestBidder == state.HighestBidder &&
+		auction.HighestBid == state.HighestBid &&
+		(state.HighestBidder == "" || auction.TokenAddress == state.TokenAddress) {
+		return false, nil
+	}
+
+	updates := map[string]interface{}{
+		"seller":         state.Seller,
+		"nft_contract":   state.NFTContract,
+		"token_id":       state.TokenID,
+		"start_price":    state.StartPrice,
+		"duration":       state.Duration,
+		"start_time":     state.StartTime,
+		"ended":          state.Ended,
+		"highest_bidder": state.HighestBidder,
+		"highest_bid":    state.HighestBid,
+		"confirmed":      false,
+	}
+	if state.HighestBidder != "" {
+		updates["token_address"] = state.TokenAddress
+	}
+	if state.Ended && auction.EndedBlock == nil {
+		updates["ended_block"] = head
+	}
+
+	if err := db.Model(&models.Auction{}).Scopes(scope).Where("auction_id = ?", auctionID).
+		Updates(updates).Error; err != nil {
+		return false, fmt.Errorf("failed to update auction %d: %w", auctionID, err)
+	}
+	log.Printf("State sync corrected auction %d\n", auctionID)
+	return true, nil
+}
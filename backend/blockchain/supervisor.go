@@ -0,0 +1,168 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 监听器运行状态
+const (
+	StateStarting     = "starting"
+	StateRunning      = "running"
+	StateReconnecting = "reconnecting"
+	StateStopped      = "stopped"
+)
+
+const (
+	minReconnectBackoff = time.Second
+	maxReconnectBackoff = 2 * time.Minute
+	// 监听器持续运行超过该时长后再出错，重连退避重新从最小值开始
+	stableRunDuration = time.Minute
+)
+
+// supervisors 正在运行的监听器守护，按 IndexerKey 索引，供健康检查读取状态
+var (
+	supervisorsMu sync.RWMutex
+	supervisors   = make(map[string]*Supervisor)
+)
+
+// SupervisorStatus 监听器守护状态
+type SupervisorStatus struct {
+	State       string       `json:"state"`
+	Restarts    int          `json:"restarts"`
+	LastError   string       `json:"last_error,omitempty"`
+	LastErrorAt *time.Time   `json:"last_error_at,omitempty"`
+	NextRetryAt *time.Time   `json:"next_retry_at,omitempty"`
+	Progress    SyncProgress `json:"progress"`
+}
+
+// Supervisor 监听器守护，订阅断开后自动重连并从游标处补齐缺口
+type Supervisor struct {
+	listener *EventListener
+	cancel   context.CancelFunc // 停止该合约的监听器
+	done     chan struct{}      // Run 返回后关闭
+
+	mu     sync.RWMutex
+	status SupervisorStatus
+}
+
+// NewSupervisor 创建监听器守护
+func NewSupervisor(listener *EventListener) *Supervisor {
+	return &Supervisor{
+		listener: listener,
+		status:   SupervisorStatus{State: StateStarting},
+	}
+}
+
+// IndexerKey 监听器守护的索引键，格式为 链ID:合约地址（小写），不同链上的合约地址可能相同
+func IndexerKey(chainID uint64, address string) string {
+	return fmt.Sprintf("%d:%s", chainID, strings.ToLower(address))
+}
+
+// GetSupervisor 获取链上合约的监听器守护，未启动时返回 nil
+func GetSupervisor(chainID uint64, address string) *Supervisor {
+	supervisorsMu.RLock()
+	defer supervisorsMu.RUnlock()
+	return supervisors[IndexerKey(chainID, address)]
+}
+
+// Supervisors 获取所有正在运行的监听器守护，按 IndexerKey 索引
+func Supervisors() map[string]*Supervisor {
+	supervisorsMu.RLock()
+	defer supervisorsMu.RUnlock()
+
+	result := make(map[string]*Supervisor, len(supervisors))
+	for key, s := range supervisors {
+		result[key] = s
+	}
+	return result
+}
+
+// Run 运行监听器，出错时按指数退避重连并重新订阅，直到 ctx 取消
+func (s *Supervisor) Run(ctx context.Context) {
+	backoff := minReconnectBackoff
+
+	for {
+		s.setState(StateRunning)
+		startedAt := time.Now()
+
+		// StartListening 每次都会从持久化的游标恢复，断线期间的日志会先被补齐
+		err := s.listener.StartListening(ctx)
+		if ctx.Err() != nil || err == nil {
+			s.setState(StateStopped)
+			return
+		}
+
+		if time.Since(startedAt) > stableRunDuration {
+			backoff = minReconnectBackoff
+		}
+
+		for {
+			s.recordFailure(err, backoff)
+			log.Printf("Event listener for %s failed: %v, reconnecting in %s\n", s.listener.contractKey(), err, backoff)
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				s.setState(StateStopped)
+				return
+			}
+
+			backoff *= 2
+			if backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+
+			if err = s.listener.reconnect(ctx); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// Status 返回守护当前状态
+func (s *Supervisor) Status() SupervisorStatus {
+	s.mu.RLock()
+	status := s.status
+	s.mu.RUnlock()
+
+	status.Progress = s.listener.Progress()
+	return status
+}
+
+// setState 更新运行状态
+func (s *Supervisor) setState(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.State = state
+	if state == StateRunning {
+		s.status.NextRetryAt = nil
+	}
+}
+
+// recordFailure 记录失败信息并进入重连状态
+func (s *Supervisor) recordFailure(err error, backoff time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	nextRetry := now.Add(backoff)
+	s.status.State = StateReconnecting
+	s.status.Restarts++
+	s.status.LastError = err.Error()
+	s.status.LastErrorAt = &now
+	s.status.NextRetryAt = &nextRetry
+}
+
+// reconnect 确认节点池中仍有可用节点，重启后由节点池为订阅选择当前最优的节点
+func (el *EventListener) reconnect(ctx context.Context) error {
+	if _, err := el.client.BlockNumber(ctx); err != nil {
+		return err
+	}
+	log.Printf("RPC pool for chain %d is reachable, restarting listener\n", el.chainID)
+	return nil
+}
@@ -0,0 +1,261 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txTrackInterval 交易回执轮询间隔
+const txTrackInterval = 5 * time.Second
+
+// txTrackers 各链的交易跟踪实例，供状态接口读取最新区块
+var (
+	txTrackersMu sync.RWMutex
+	txTrackers   = make(map[uint64]*TxTracker)
+)
+
+// TxTracker 交易跟踪，轮询已广播交易的回执并更新生命周期状态
+type TxTracker struct {
+	contract *ContractService
+	head     atomic.Uint64 // 最近一次轮询时的最新区块
+}
+
+// NewTxTracker 创建交易跟踪实例，跟踪合约服务所在链上的交易
+func NewTxTracker(contract *ContractService) *TxTracker {
+	return &TxTracker{contract: contract}
+}
+
+// SetTxTracker 登记交易跟踪实例，每条链一个
+func SetTxTracker(t *TxTracker) {
+	txTrackersMu.Lock()
+	defer txTrackersMu.Unlock()
+	txTrackers[t.contract.chainID] = t
+}
+
+// GetTxTracker 获取链的交易跟踪实例，未启动时返回 nil
+func GetTxTracker(chainID uint64) *TxTracker {
+	txTrackersMu.RLock()
+	defer txTrackersMu.RUnlock()
+	return txTrackers[chainID]
+}
+
+// Head 返回最近一次轮询时的最新区块，尚未轮询时返回 0
+func (t *TxTracker) Head() uint64 {
+	return t.head.Load()
+}
+
+// Confirmations 计算已打包交易的确认数
+func (t *TxTracker) Confirmations(txn *models.Transaction) uint64 {
+	head := t.Head()
+	if txn.BlockNumber == nil || head < *txn.BlockNumber {
+		return 0
+	}
+	return head - *txn.BlockNumber + 1
+}
+
+// Run 定期检查未决交易，直到 ctx 取消
+func (t *TxTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(txTrackInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.poll(ctx); err != nil {
+			log.Printf("Transaction tracking error: %v\n", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// poll 检查所有待打包交易，以及尚未达到确认深度、仍可能被重组移出的已打包交易
+func (t *TxTracker) poll(ctx context.Context) error {
+	head, err := t.contract.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	t.head.Store(head)
+
+	var safeBlock uint64
+	if head > config.AppConfig.Confirmations {
+		safeBlock = head - config.AppConfig.Confirmations
+	}
+
+	db := database.GetDB()
+	var txns []models.Transaction
+	if err := db.Where("chain_id = ?", t.contract.chainID).
+		Where(db.Where("status = ?", models.TxStatusPending).
+			Or("status IN ? AND block_number > ?", []string{models.TxStatusMined, models.TxStatusFailed}, safeBlock)).
+		Order("submitted_at ASC").
+		Find(&txns).Error; err != nil {
+		return fmt.Errorf("failed to load transactions: %w", err)
+	}
+
+	for i := range txns {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := t.check(ctx, &txns[i]); err != nil {
+			log.Printf("Failed to check transaction %s: %v\n", txns[i].TxHash, err)
+		}
+	}
+	return nil
+}
+
+// check 根据回执、交易池和账户 nonce 更新单笔交易的状态
+func (t *TxTracker) check(ctx context.Context, txn *models.Transaction) error {
+	hash := common.HexToHash(txn.TxHash)
+	now := time.Now()
+	txn.LastCheckedAt = &now
+
+	receipt, err := t.contract.client.TransactionReceipt(ctx, hash)
+	if err == nil {
+		t.applyReceipt(txn, receipt)
+		return t.save(txn)
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("failed to get receipt: %w", err)
+	}
+
+	// 之前已打包的交易失去回执，说明所在区块被重组移出，重新回到待打包状态
+	if txn.Status != models.TxStatusPending {
+		log.Printf("Transaction %s was removed by a chain reorganization\n", txn.TxHash)
+		t.resetToPending(txn)
+		return t.save(txn)
+	}
+
+	// 账户的 nonce 已越过该交易，说明同一 nonce 已被其他交易（加速或取消）使用
+	nonce, err := t.contract.client.NonceAt(ctx, common.HexToAddress(txn.FromAddress), nil)
+	if err != nil {
+		return fmt.Errorf("failed to get account nonce: %w", err)
+	}
+	if nonce > txn.Nonce {
+		// nonce 查询期间交易可能刚被打包，再确认一次回执
+		if receipt, err := t.contract.client.TransactionReceipt(ctx, hash); err == nil {
+			t.applyReceipt(txn, receipt)
+			return t.save(txn)
+		}
+		t.markReplaced(txn)
+		return t.save(txn)
+	}
+
+	// 交易既未打包也不在交易池中，超时后视为被丢弃
+	_, _, err = t.contract.client.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		timeout := time.Duration(config.AppConfig.TxDropTimeout) * time.Second
+		if time.Since(txn.SubmittedAt) > timeout {
+			log.Printf("Transaction %s dropped from mempool\n", txn.TxHash)
+			txn.Status = models.TxStatusDropped
+			// 被丢弃的 nonce 会在服务端账户的序列中留下空缺，下次分配时从节点重新同步
+			nonces.reset(t.contract.chainID, common.HexToAddress(txn.FromAddress))
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return t.save(txn)
+}
+
+// applyReceipt 根据回执记录打包结果
+func (t *TxTracker) applyReceipt(txn *models.Transaction, receipt *types.Receipt) {
+	blockNumber := receipt.BlockNumber.Uint64()
+	gasUsed := receipt.GasUsed
+
+	if txn.BlockNumber == nil || *txn.BlockNumber != blockNumber {
+		now := time.Now()
+		txn.MinedAt = &now
+	}
+	txn.BlockNumber = &blockNumber
+	txn.BlockHash = receipt.BlockHash.Hex()
+	txn.GasUsed = &gasUsed
+	if receipt.EffectiveGasPrice != nil {
+		txn.EffectiveGasPrice = receipt.EffectiveGasPrice.String()
+	}
+	txn.ReplacedBy = ""
+
+	status := models.TxStatusMined
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = models.TxStatusFailed
+	}
+	if txn.Status != status {
+		log.Printf("Transaction %s %s in block %d\n", txn.TxHash, status, blockNumber)
+	}
+	txn.Status = status
+}
+
+// resetToPending 清除打包信息，交易回到待打包状态
+func (t *TxTracker) resetToPending(txn *models.Transaction) {
+	txn.Status = models.TxStatusPending
+	txn.BlockNumber = nil
+	txn.BlockHash = ""
+	txn.GasUsed = nil
+	txn.EffectiveGasPrice = ""
+	txn.MinedAt = nil
+}
+
+// markReplaced 标记交易已被同一 nonce 的其他交易替代，并尽量找出替代交易
+func (t *TxTracker) markReplaced(txn *models.Transaction) {
+	txn.Status = models.TxStatusReplaced
+
+	var replacement models.Transaction
+	err := database.GetDB().
+		Where("chain_id = ? AND from_address = ? AND nonce = ? AND tx_hash <> ? AND status IN ?",
+			txn.ChainID, txn.FromAddress, txn.Nonce, txn.TxHash, []string{models.TxStatusMined, models.TxStatusFailed}).
+		Order("submitted_at DESC").
+		First(&replacement).Error
+	if err == nil {
+		txn.ReplacedBy = replacement.TxHash
+	}
+	log.Printf("Transaction %s replaced (by %q)\n", txn.TxHash, txn.ReplacedBy)
+}
+
+// save 保存交易状态
+func (t *TxTracker) save(txn *models.Transaction) error {
+	if err := database.GetDB().Save(txn).Error; err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+	return nil
+}
+
+// recordTransaction 记录已广播的交易，由跟踪器后续轮询其状态
+func recordTransaction(chainID *big.Int, tx *types.Transaction, from common.Address, method string, args []interface{}) error {
+	txn := models.Transaction{
+		TxHash:      tx.Hash().Hex(),
+		ChainID:     chainID.Uint64(),
+		FromAddress: strings.ToLower(from.Hex()),
+		ToAddress:   strings.ToLower(tx.To().Hex()),
+		Nonce:       tx.Nonce(),
+		Method:      method,
+		Value:       tx.Value().String(),
+		Status:      models.TxStatusPending,
+		SubmittedAt: time.Now(),
+	}
+	// placeBid 和 endAuction 的第一个参数都是拍卖ID，createAuction 的拍卖ID在打包后才确定
+	if userMethods[method] && len(args) > 0 {
+		if auctionID, ok := args[0].(*big.Int); ok {
+			id := uint(auctionID.Uint64())
+			txn.AuctionID = &id
+		}
+	}
+
+	if err := database.GetDB().Create(&txn).Error; err != nil {
+		return fmt.Errorf("failed to record transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrInvalidTransaction 签名交易校验失败
+var ErrInvalidTransaction = errors.New("invalid transaction")
+
+// ErrTxNotPending 交易已打包或已不在交易池中，无法替换
+var ErrTxNotPending = errors.New("transaction is not pending")
+
+// userMethods 允许用户通过广播接口提交的合约方法
+var userMethods = map[string]bool{
+	"placeBid":   true,
+	"endAuction": true,
+}
+
+// UnsignedTx 待用户钱包签名的交易
+type UnsignedTx struct {
+	From                 string `json:"from"`
+	To                   string `json:"to"`
+	Method               string `json:"method"`
+	Data                 string `json:"data"`                               // 调用数据（hex）
+	Value                string `json:"value"`                              // 附带的 ETH（wei）
+	Gas                  uint64 `json:"gas"`                                // 预估的 gas limit
+	Type                 uint8  `json:"type"`                               // 0 为传统交易，2 为 EIP-1559 交易
+	GasPrice             string `json:"gas_price,omitempty"`                // 传统交易的 gas price（wei）
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty"`          // EIP-1559 交易的费用上限（wei）
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty"` // EIP-1559 交易的小费（wei）
+	Nonce                uint64 `json:"nonce"`
+	ChainID              string `json:"chain_id"`
+}
+
+// BuildPlaceBidTx 构造出价交易，ETH 出价时 value 为出价金额
+func (cs *ContractService) BuildPlaceBidTx(ctx context.Context, from common.Address, auctionID, amount *big.Int, tokenAddress common.Address) (*UnsignedTx, error) {
+	value := big.NewInt(0)
+	if tokenAddress == (common.Address{}) {
+		value = amount
+	}
+	return cs.buildTx(ctx, from, value, "placeBid", auctionID, amount, tokenAddress)
+}
+
+// BuildEndAuctionTx 构造结束拍卖交易
+func (cs *ContractService) BuildEndAuctionTx(ctx context.Context, from common.Address, auctionID *big.Int) (*UnsignedTx, error) {
+	return cs.buildTx(ctx, from, big.NewInt(0), "endAuction", auctionID)
+}
+
+// preparedTx 已完成模拟和费用计算、尚未分配 nonce 的交易
+type preparedTx struct {
+	method  string
+	args    []interface{}
+	data    []byte
+	value   *big.Int
+	gas     uint64
+	fees    *txFees
+	chainID *big.Int
+}
+
+// prepareTx 准备拍卖合约调用
+func (cs *ContractService) prepareTx(ctx context.Context, from common.Address, value *big.Int, method string, args ...interface{}) (*preparedTx, error) {
+	return cs.prepareCall(ctx, from, cs.contractAddress, &cs.contractABI, value, method, args...)
+}
+
+// prepareCall 打包调用数据，模拟执行并计算 gas 和费用，会被合约拒绝的交易返回 *RevertError
+func (cs *ContractService) prepareCall(ctx context.Context, from, to common.Address, contractABI *abi.ABI, value *big.Int, method string, args ...interface{}) (*preparedTx, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+
+	fees, err := cs.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := cs.client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	gas, err := cs.preflight(ctx, method, ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &preparedTx{
+		method:  method,
+		args:    args,
+		data:    data,
+		value:   value,
+		gas:     gas,
+		fees:    fees,
+		chainID: chainID,
+	}, nil
+}
+
+// transaction 使用指定 nonce 生成待签名交易
+func (p *preparedTx) transaction(to common.Address, nonce uint64) *types.Transaction {
+	if p.fees.DynamicFee {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   p.chainID,
+			Nonce:     nonce,
+			GasTipCap: p.fees.GasTipCap,
+			GasFeeCap: p.fees.GasFeeCap,
+			Gas:       p.gas,
+			To:        &to,
+			Value:     p.value,
+			Data:      p.data,
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: p.fees.GasPrice,
+		Gas:      p.gas,
+		To:       &to,
+		Value:    p.value,
+		Data:     p.data,
+	})
+}
+
+// buildTx 构造由调用方钱包签名的拍卖合约交易
+func (cs *ContractService) buildTx(ctx context.Context, from common.Address, value *big.Int, method string, args ...interface{}) (*UnsignedTx, error) {
+	return cs.buildCall(ctx, from, cs.contractAddress, &cs.contractABI, value, method, args...)
+}
+
+// buildCall 构造由调用方钱包签名的交易，nonce 取节点的 pending nonce
+func (cs *ContractService) buildCall(ctx context.Context, from, to common.Address, contractABI *abi.ABI, value *big.Int, method string, args ...interface{}) (*UnsignedTx, error) {
+	// 模拟执行并预估 gas，会被合约拒绝的交易不交给用户签名
+	prepared, err := cs.prepareCall(ctx, from, to, contractABI, value, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := cs.client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	unsigned := &UnsignedTx{
+		From:    from.Hex(),
+		To:      to.Hex(),
+		Method:  method,
+		Data:    hexutil.Encode(prepared.data),
+		Value:   value.String(),
+		Gas:     prepared.gas,
+		Type:    prepared.fees.txType(),
+		Nonce:   nonce,
+		ChainID: prepared.chainID.String(),
+	}
+	if prepared.fees.DynamicFee {
+		unsigned.MaxFeePerGas = prepared.fees.GasFeeCap.String()
+		unsigned.MaxPriorityFeePerGas = prepared.fees.GasTipCap.String()
+	} else {
+		unsigned.GasPrice = prepared.fees.GasPrice.String()
+	}
+	return unsigned, nil
+}
+
+// Transact 以服务端签名账户签名并广播合约调用，所有服务端发起的写操作都经过这里
+// nonce 由全局 nonce 管理分配，同一账户的并发提交按顺序获得连续的 nonce
+func (cs *ContractService) Transact(ctx context.Context, value *big.Int, method string, args ...interface{}) (*types.Transaction, error) {
+	if cs.signer == nil {
+		return nil, ErrNoSigner
+	}
+	from := cs.signer.Address()
+
+	prepared, err := cs.prepareTx(ctx, from, value, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var signed *types.Transaction
+	err = nonces.submit(ctx, cs.client, from, func(nonce uint64) error {
+		tx, err := cs.signer.SignTx(ctx, prepared.transaction(cs.contractAddress, nonce), prepared.chainID)
+		if err != nil {
+			return fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		signed = tx
+		if err := cs.client.SendTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("failed to send transaction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordTransaction(prepared.chainID, signed, from, method, args); err != nil {
+		log.Printf("Failed to track transaction %s: %v\n", signed.Hash().Hex(), err)
+	}
+	return signed, nil
+}
+
+// ReplaceTransaction 以提高后的费用重新签名并发送服务端账户的待打包交易，nonce 不变
+// 调用数据按 method 和 args 重新打包并模拟，原交易已打包或不在交易池中时返回 ErrTxNotPending
+func (cs *ContractService) ReplaceTransaction(ctx context.Context, hash common.Hash, bumpPercent int64, method string, args ...interface{}) (*types.Transaction, error) {
+	if cs.signer == nil {
+		return nil, ErrNoSigner
+	}
+	from := cs.signer.Address()
+
+	old, isPending, err := cs.client.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) || (err == nil && !isPending) {
+		return nil, ErrTxNotPending
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	prepared, err := cs.prepareTx(ctx, from, old.Value(), method, args...)
+	if err != nil {
+		return nil, err
+	}
+	prepared.fees.bump(old, bumpPercent)
+
+	var signed *types.Transaction
+	err = nonces.replace(cs.chainID, from, func() error {
+		tx, err := cs.signer.SignTx(ctx, prepared.transaction(cs.contractAddress, old.Nonce()), prepared.chainID)
+		if err != nil {
+			return fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		signed = tx
+		if err := cs.client.SendTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("failed to send transaction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordTransaction(prepared.chainID, signed, from, method, args); err != nil {
+		log.Printf("Failed to track transaction %s: %v\n", signed.Hash().Hex(), err)
+	}
+	return signed, nil
+}
+
+// BroadcastRawTx 校验用户签名的交易并广播
+// 交易必须发往拍卖合约、链ID一致，且调用的是允许的方法（或授权拍卖合约的代币 approve）；expectedMethod 非空时还需与之一致
+func (cs *ContractService) BroadcastRawTx(ctx context.Context, rawTx string, expectedMethod string) (*types.Transaction, common.Address, error) {
+	raw, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("%w: malformed hex: %v", ErrInvalidTransaction, err)
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, common.Address{}, fmt.Errorf("%w: failed to decode: %v", ErrInvalidTransaction, err)
+	}
+
+	chainID, err := cs.client.ChainID(ctx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if tx.ChainId().Cmp(chainID) != 0 {
+		return nil, common.Address{}, fmt.Errorf("%w: chain ID %s does not match %s", ErrInvalidTransaction, tx.ChainId(), chainID)
+	}
+
+	if len(tx.Data()) < 4 {
+		return nil, common.Address{}, fmt.Errorf("%w: missing method selector", ErrInvalidTransaction)
+	}
+
+	var method *abi.Method
+	var args []interface{}
+	switch {
+	case tx.To() == nil:
+		return nil, common.Address{}, fmt.Errorf("%w: contract creation is not allowed", ErrInvalidTransaction)
+	case *tx.To() == cs.contractAddress:
+		method, err = cs.contractABI.MethodById(tx.Data()[:4])
+		if err != nil || !userMethods[method.Name] {
+			return nil, common.Address{}, fmt.Errorf("%w: method is not allowed", ErrInvalidTransaction)
+		}
+		args, err = method.Inputs.Unpack(tx.Data()[4:])
+		if err != nil {
+			return nil, common.Address{}, fmt.Errorf("%w: malformed %s arguments: %v", ErrInvalidTransaction, method.Name, err)
+		}
+	default:
+		// ERC-20 出价前的授权交易发往代币合约，只放行授权给拍卖合约的 approve
+		var ok bool
+		method, args, ok = cs.approvalMethod(tx.Data())
+		if !ok {
+			return nil, common.Address{}, fmt.Errorf("%w: transaction is not sent to the auction contract", ErrInvalidTransaction)
+		}
+	}
+	if expectedMethod != "" && method.Name != expectedMethod {
+		return nil, common.Address{}, fmt.Errorf("%w: expected %s, got %s", ErrInvalidTransaction, expectedMethod, method.Name)
+	}
+
+	// 签名无效时无法恢复发送方
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("%w: invalid signature: %v", ErrInvalidTransaction, err)
+	}
+
+	// 签名后到广播前状态可能已变化（例如他人出了更高价），广播前再模拟一次
+	if err := cs.simulate(ctx, method.Name, ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}); err != nil {
+		return nil, common.Address{}, err
+	}
+
+	if err := cs.client.SendTransaction(ctx, tx); err != nil {
+		return nil, common.Address{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	// 交易已广播，记录失败不影响返回结果，只是无法通过状态接口查询
+	if err := recordTransaction(chainID, tx, from, method.Name, args); err != nil {
+		log.Printf("Failed to track transaction %s: %v\n", tx.Hash().Hex(), err)
+	}
+	return tx, from, nil
+}
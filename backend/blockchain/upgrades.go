@@ -0,0 +1,314 @@
+package blockchain
+
+import (
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+// NftAuctionV2 升级版合约绑定由 abi/NFTAuctionV2.abi.json 生成
+//go:generate abigen --abi abi/NFTAuctionV2.abi.json --pkg blockchain --type NftAuctionV2 --out nft_auction_v2.go
+
+// implementationSlot ERC-1967 实现合约地址存储槽，即 keccak256("eip1967.proxy.implementation") - 1
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// defaultABIVersion 没有实现历史或无法识别实现时使用的 ABI 版本
+const defaultABIVersion = "v1"
+
+// auctionABIs 各版本拍卖合约的 ABI
+var auctionABIs = map[string]*bind.MetaData{
+	"v1": NftAuctionMetaData,
+	"v2": NftAuctionV2MetaData,
+}
+
+// pushSelector 合约分发逻辑中比较方法选择器使用的 PUSH4 操作码
+const pushSelector = 0x63
+
+// auctionABI 获取指定版本的 ABI
+func auctionABI(version string) (*abi.ABI, error) {
+	meta, ok := auctionABIs[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown ABI version %q", version)
+	}
+	parsed, err := meta.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s ABI: %w", version, err)
+	}
+	return parsed, nil
+}
+
+// isAuctionEventTopic topic 是否为任一版本拍卖合约 ABI 中的事件
+func isAuctionEventTopic(topic common.Hash) bool {
+	for version := range auctionABIs {
+		parsed, err := auctionABI(version)
+		if err != nil {
+			continue
+		}
+		for _, event := range parsed.Events {
+			if event.ID == topic {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// configuredABIVersion 读取 IMPLEMENTATION_ABI 中为实现合约指定的 ABI 版本
+func configuredABIVersion(implementation common.Address) (string, bool) {
+	for _, entry := range strings.Split(config.AppConfig.ImplementationABI, ",") {
+		address, version, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || !common.IsHexAddress(address) {
+			continue
+		}
+		if common.HexToAddress(address) == implementation {
+			if _, known := auctionABIs[version]; known {
+				return version, true
+			}
+			log.Printf("Ignoring unknown ABI version %q for implementation %s\n", version, implementation.Hex())
+		}
+	}
+	return "", false
+}
+
+// detectABIVersion 确定实现合约对应的 ABI 版本：优先使用配置，否则比对字节码中出现的方法选择器
+// 选择器全部出现的比例最高者胜出，比例相同时取匹配方法更多的版本
+func detectABIVersion(ctx context.Context, client bind.ContractCaller, implementation common.Address) (string, error) {
+	if version, ok := configuredABIVersion(implementation); ok {
+		return version, nil
+	}
+	if client == nil {
+		return defaultABIVersion, nil
+	}
+
+	code, err := client.CodeAt(ctx, implementation, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get implementation code: %w", err)
+	}
+	if len(code) == 0 {
+		log.Printf("Implementation %s has no code, using ABI %s\n", implementation.Hex(), defaultABIVersion)
+		return defaultABIVersion, nil
+	}
+
+	versions := make([]string, 0, len(auctionABIs))
+	for version := range auctionABIs {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	best, bestScore, bestFound := defaultABIVersion, -1.0, 0
+	for _, version := range versions {
+		parsed, err := auctionABI(version)
+		if err != nil {
+			return "", err
+		}
+		found := 0
+		for _, method := range parsed.Methods {
+			if bytes.Contains(code, append([]byte{pushSelector}, method.ID...)) {
+				found++
+			}
+		}
+		score := float64(found) / float64(len(parsed.Methods))
+		if score > bestScore || (score == bestScore && found > bestFound) {
+			best, bestScore, bestFound = version, score, found
+		}
+	}
+	return best, nil
+}
+
+// loadImplementations 加载实现历史到内存，按生效区块排序
+func (el *EventListener) loadImplementations() error {
+	var periods []models.Implementation
+	if err := database.GetDB().
+		Where("chain_id = ? AND proxy_address = ?", el.chainID, el.contractKey()).
+		Order("from_block ASC").
+		Find(&periods).Error; err != nil {
+		return fmt.Errorf("failed to load implementations: %w", err)
+	}
+	el.implementations = periods
+	el.implLoaded = true
+	return nil
+}
+
+// abiAt 返回解码指定区块的日志和调用应使用的 ABI
+// 注册表中固定了版本时始终使用该版本，否则按实现历史选择，没有对应的实现历史时使用默认 ABI
+func (el *EventListener) abiAt(blockNumber uint64) *abi.ABI {
+	if el.abiVersion != "" {
+		parsed, err := auctionABI(el.abiVersion)
+		if err == nil {
+			return parsed
+		}
+		log.Printf("Contract %s: %v, using implementation history\n", el.contractKey(), err)
+	}
+
+	if !el.implLoaded {
+		if err := el.loadImplementations(); err != nil {
+			log.Printf("Failed to load implementation history, using default ABI: %v\n", err)
+			return &el.contractABI
+		}
+	}
+
+	for i := len(el.implementations) - 1; i >= 0; i-- {
+		period := el.implementations[i]
+		if period.FromBlock > blockNumber {
+			continue
+		}
+		if period.ToBlock != nil && *period.ToBlock < blockNumber {
+			break
+		}
+		parsed, err := auctionABI(period.ABIVersion)
+		if err != nil {
+			log.Printf("Implementation %s: %v, using default ABI\n", period.Implementation, err)
+			return &el.contractABI
+		}
+		return parsed
+	}
+	return &el.contractABI
+}
+
+// isUpgradeLog 是否为代理合约的 Upgraded 事件，该事件由代理发出，与实现版本无关
+func (el *EventListener) isUpgradeLog(vLog types.Log) bool {
+	event, ok := el.contractABI.Events["Upgraded"]
+	return ok && len(vLog.Topics) > 0 && vLog.Topics[0] == event.ID
+}
+
+// upgradeMethod 识别 upgradeToAndCall 调用，升级后的实现可能不再包含该方法，因此按默认 ABI 识别
+func (el *EventListener) upgradeMethod(selector []byte) *abi.Method {
+	method, ok := el.contractABI.Methods["upgradeToAndCall"]
+	if !ok || !bytes.Equal(method.ID, selector) {
+		return nil
+	}
+	return &method
+}
+
+// handleUpgraded 处理代理合约升级事件，新实现从下一个区块开始用于解码
+// 同一区块内其余日志仍按旧版本解析，旧版本的事件是新版本的超集时不会丢失
+func (el *EventListener) handleUpgraded(tx *gorm.DB, vLog types.Log) error {
+	event, err := el.filterer.ParseUpgraded(vLog)
+	if err != nil {
+		return fmt.Errorf("%w: failed to unpack Upgraded event: %v", errDecode, err)
+	}
+	return el.recordImplementation(tx, event.Implementation, vLog.BlockNumber+1, vLog.TxHash.Hex())
+}
+
+// recordImplementation 记录从 blockNumber 开始生效的实现，并结束上一个实现的区间
+func (el *EventListener) recordImplementation(tx *gorm.DB, implementation common.Address, blockNumber uint64, txHash string) error {
+	impl := strings.ToLower(implementation.Hex())
+
+	var current models.Implementation
+	err := tx.Where("chain_id = ? AND proxy_address = ? AND to_block IS NULL", el.chainID, el.contractKey()).
+		Order("from_block DESC").First(&current).Error
+	switch {
+	case err == nil:
+		if current.Implementation == impl {
+			return nil
+		}
+		if current.FromBlock >= blockNumber {
+			// 只会在重放早于已知历史的日志时出现，已知历史以更晚的记录为准
+			log.Printf("Ignoring implementation %s at block %d, history already starts at block %d\n",
+				impl, blockNumber, current.FromBlock)
+			return nil
+		}
+		end := blockNumber - 1
+		if err := tx.Model(&current).Update("to_block", end).Error; err != nil {
+			return fmt.Errorf("failed to close implementation period: %w", err)
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("failed to find current implementation: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var caller bind.ContractCaller
+	if el.client != nil {
+		caller = el.client
+	}
+	version, err := detectABIVersion(ctx, caller, implementation)
+	if err != nil {
+		return err
+	}
+
+	period := models.Implementation{
+		ChainID:        el.chainID,
+		ProxyAddress:   el.contractKey(),
+		Implementation: impl,
+		ABIVersion:     version,
+		FromBlock:      blockNumber,
+		TxHash:         txHash,
+	}
+	if err := tx.Create(&period).Error; err != nil {
+		return fmt.Errorf("failed to record implementation: %w", err)
+	}
+
+	// 事务可能回滚，下次使用时从数据库重新加载
+	el.implLoaded = false
+	log.Printf("Contract implementation %s (ABI %s) effective from block %d\n", impl, version, blockNumber)
+	return nil
+}
+
+// checkImplementation 读取 ERC-1967 存储槽，发现与记录不一致的实现时从最新区块开始记录
+// 用于不发 Upgraded 事件或未解码到升级调用的情况，只应在已同步到最新区块后调用
+func (el *EventListener) checkImplementation(ctx context.Context) error {
+	head, err := el.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	value, err := el.client.StorageAt(ctx, el.contractAddress, implementationSlot, new(big.Int).SetUint64(head))
+	if err != nil {
+		return fmt.Errorf("failed to read implementation slot: %w", err)
+	}
+	implementation := common.BytesToAddress(value)
+	if implementation == (common.Address{}) {
+		// 不是代理合约
+		return nil
+	}
+
+	// 尚无任何历史时，当前实现视为从创世区块开始生效
+	var count int64
+	if err := database.GetDB().Model(&models.Implementation{}).
+		Where("chain_id = ? AND proxy_address = ?", el.chainID, el.contractKey()).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count implementations: %w", err)
+	}
+	fromBlock := head
+	if count == 0 {
+		fromBlock = 0
+	}
+
+	return database.GetDB().Transaction(func(tx *gorm.DB) error {
+		return el.recordImplementation(tx, implementation, fromBlock, "")
+	})
+}
+
+// rollbackImplementations 删除分叉点及之后开始的实现区间，并重新打开之前的实现
+func (el *EventListener) rollbackImplementations(tx *gorm.DB, fromBlock uint64) error {
+	scope := tx.Where("chain_id = ? AND proxy_address = ?", el.chainID, el.contractKey())
+	if err := scope.Session(&gorm.Session{}).Where("from_block >= ?", fromBlock).
+		Delete(&models.Implementation{}).Error; err != nil {
+		return fmt.Errorf("failed to delete orphaned implementations: %w", err)
+	}
+	if fromBlock > 0 {
+		if err := scope.Session(&gorm.Session{}).Model(&models.Implementation{}).
+			Where("to_block >= ?", fromBlock-1).
+			Update("to_block", nil).Error; err != nil {
+			return fmt.Errorf("failed to reopen implementation: %w", err)
+		}
+	}
+	el.implLoaded = false
+	return nil
+}
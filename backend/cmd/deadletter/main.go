@@ -0,0 +1,126 @@
+// deadletter 死信队列命令行工具
+//
+// 用法:
+//
+//	go run ./cmd/deadletter list [-status pending] [-limit 50]
+//	go run ./cmd/deadletter show <id>
+//	go run ./cmd/deadletter retry <id>
+//	go run ./cmd/deadletter discard <id>
+package main
+
+import (
+	"auction-backend/blockchain"
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	// 加载配置
+	if err := config.LoadConfig(); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// 初始化数据库
+	if err := database.InitDB(config.AppConfig.GetDSN()); err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "list":
+		listCmd(os.Args[2:])
+	case "show":
+		letter := mustLoad(os.Args[2:])
+		printJSON(letter)
+	case "retry":
+		letter, err := blockchain.RetryDeadLetter(mustID(os.Args[2:]))
+		if err != nil {
+			log.Fatalf("Failed to retry dead letter: %v", err)
+		}
+		printJSON(letter)
+	case "discard":
+		letter, err := blockchain.DiscardDeadLetter(mustID(os.Args[2:]))
+		if err != nil {
+			log.Fatalf("Failed to discard dead letter: %v", err)
+		}
+		printJSON(letter)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+// listCmd 列出死信
+func listCmd(args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	status := fs.String("status", models.DeadLetterPending, "pending, resolved, discarded or all")
+	limit := fs.Int("limit", 50, "maximum number of rows")
+	fs.Parse(args)
+
+	query := database.GetDB().Model(&models.DeadLetter{})
+	if *status != "all" {
+		query = query.Where("status = ?", *status)
+	}
+
+	var letters []models.DeadLetter
+	if err := query.Order("block_number ASC, log_index ASC").Limit(*limit).Find(&letters).Error; err != nil {
+		log.Fatalf("Failed to query dead letters: %v", err)
+	}
+
+	fmt.Printf("%-6s %-10s %-10s %-16s %-68s %s\n", "ID", "STATUS", "BLOCK", "EVENT", "TX#LOG", "ERROR")
+	for _, letter := range letters {
+		fmt.Printf("%-6d %-10s %-10d %-16s %-68s %s\n",
+			letter.ID, letter.Status, letter.BlockNumber, letter.EventName,
+			fmt.Sprintf("%s#%d", letter.TxHash, letter.LogIndex), letter.Error)
+	}
+}
+
+// mustLoad 按 ID 加载死信
+func mustLoad(args []string) *models.DeadLetter {
+	var letter models.DeadLetter
+	if err := database.GetDB().First(&letter, mustID(args)).Error; err != nil {
+		log.Fatalf("Failed to load dead letter: %v", err)
+	}
+	return &letter
+}
+
+// mustID 解析命令参数中的死信 ID
+func mustID(args []string) uint {
+	if len(args) < 1 {
+		usage()
+		os.Exit(2)
+	}
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid dead letter ID: %s", args[0])
+	}
+	return uint(id)
+}
+
+// printJSON 以 JSON 格式输出
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: deadletter <list|show|retry|discard> [args]")
+	fmt.Fprintln(os.Stderr, "  list [-status pending|resolved|discarded|all] [-limit N]")
+	fmt.Fprintln(os.Stderr, "  show <id>")
+	fmt.Fprintln(os.Stderr, "  retry <id>")
+	fmt.Fprintln(os.Stderr, "  discard <id>")
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -17,17 +19,57 @@ type Config struct {
 	DBName     string
 
 	// 区块链配置
-	ETHRPCURL       string
-	ContractAddress string
-	StartBlock      uint64
+	ETHRPCURL         string              // 默认链的 RPC 地址，多个以逗号分隔
+	ChainRPCURLs      map[uint64][]string // 各链的 RPC 地址，如 1=https://...,1=https://...,137=https://...，ETH_RPC_URL 所在的链为默认链
+	RPCProbeInterval  int                 // 探测 RPC 节点延迟和最新区块的间隔（秒）
+	RPCMaxHeadLag     uint64              // 节点最新区块落后同链最高节点超过该区块数时视为不健康
+	ContractAddress   string
+	StartBlock        uint64
+	ReorgDepth        uint64 // 检测链重组时回溯的区块数
+	Confirmations     uint64 // 事件被视为最终确认所需的区块确认数
+	LogChunkSize      uint64 // 单次 FilterLogs 查询的最大区块跨度
+	PollInterval      int    // 轮询模式下的查询间隔（秒）
+	IndexMode         string // 索引模式：events 订阅事件，calls 解码交易调用，state 仅读取合约状态
+	StateSyncInterval int    // 读取合约状态校正本地数据的间隔（秒），0 表示关闭
+	ReconcileInterval int    // 拍卖链上对账的间隔（秒），0 表示关闭
+	ImplementationABI string // 实现合约地址与 ABI 版本的对应，如 0xabc...=v1,0xdef...=v2，未列出的按字节码自动识别
+
+	// 交易费用配置
+	FeeStrategy        string  // EIP-1559 小费策略：slow, standard, fast
+	MaxFeePerGasGwei   float64 // maxFeePerGas 上限（gwei），0 表示不限制
+	MaxPriorityFeeGwei float64 // maxPriorityFeePerGas 上限（gwei），0 表示不限制
+	TxDropTimeout      int     // 交易不在交易池且未打包超过该时长（秒）后视为被丢弃
+
+	// 价格预言机配置
+	PriceFeedMaxAge int // 预言机报价超过该时长（秒）未更新视为过期
+
+	// 自动结束拍卖配置
+	KeeperInterval    int   // 扫描已到期拍卖的间隔（秒），0 表示关闭
+	KeeperLeaseTTL    int   // 多实例部署时持有执行租约的时长（秒）
+	KeeperMaxAttempts int   // 单个拍卖最多提交 endAuction 的次数
+	KeeperRetryDelay  int   // 提交失败后的首次重试间隔（秒），之后按指数增长
+	KeeperBumpAfter   int   // 交易超过该时长（秒）未打包时提高费用重新发送
+	KeeperBumpPercent int64 // 重新发送时费用提高到原交易的百分比，节点要求至少 110
+
+	// 服务端签名账户配置
+	SignerType             string // 签名方式：none, keystore, remote, local
+	SignerKeystorePath     string // keystore 文件路径
+	SignerKeystorePassword string // keystore 密码
+	SignerAddress          string // 远程签名账户地址
+	SignerRemoteURL        string // 远程签名服务地址
+	SignerRemoteKeyID      string // 远程签名服务中的密钥ID
+	SignerRemoteToken      string // 远程签名服务访问令牌
+	SignerLocalKey         string // 本地替身使用的私钥，仅用于开发和测试
 
 	// 服务器配置
 	ServerPort string
-	
+	AdminToken string // 管理接口访问令牌，为空时禁用管理接口
+
 	// Alchemy API 配置
-	AlchemyAPIKey  string
-	AlchemyBaseURL string
-	
+	AlchemyAPIKey    string
+	AlchemyBaseURL   string
+	AlchemyChainURLs map[uint64]string // 默认链以外各链的 Alchemy NFT API 地址
+
 	// OpenSea API 配置
 	OpenSeaAPIKey string
 }
@@ -42,27 +84,95 @@ func LoadConfig() error {
 	}
 
 	AppConfig = &Config{
-		DBHost:          getEnv("DB_HOST", "localhost"),
-		DBPort:          getEnv("DB_PORT", "3306"),
-		DBUser:          getEnv("DB_USER", "root"),
-		DBPassword:      getEnv("DB_PASSWORD", ""),
-		DBName:          getEnv("DB_NAME", "nft_auction"),
-		ETHRPCURL:       getEnv("ETH_RPC_URL", ""),
-		ContractAddress: getEnv("CONTRACT_ADDRESS", ""),
-		StartBlock:      uint64(getEnvAsInt("START_BLOCK", 0)),
-		ServerPort:      getEnv("SERVER_PORT", "8080"),
-		AlchemyAPIKey:   getEnv("ALCHEMY_API_KEY", ""),
-		AlchemyBaseURL:  getEnv("ALCHEMY_BASE_URL", "https://eth-mainnet.g.alchemy.com/nft/v3"),
-		OpenSeaAPIKey:   getEnv("OPENSEA_API_KEY", ""),
+		DBHost:                 getEnv("DB_HOST", "localhost"),
+		DBPort:                 getEnv("DB_PORT", "3306"),
+		DBUser:                 getEnv("DB_USER", "root"),
+		DBPassword:             getEnv("DB_PASSWORD", ""),
+		DBName:                 getEnv("DB_NAME", "nft_auction"),
+		ETHRPCURL:              getEnv("ETH_RPC_URL", ""),
+		RPCProbeInterval:       getEnvAsInt("RPC_PROBE_INTERVAL", 15),
+		RPCMaxHeadLag:          uint64(getEnvAsInt("RPC_MAX_HEAD_LAG", 5)),
+		ContractAddress:        getEnv("CONTRACT_ADDRESS", ""),
+		StartBlock:             uint64(getEnvAsInt("START_BLOCK", 0)),
+		ReorgDepth:             uint64(getEnvAsInt("REORG_DEPTH", 64)),
+		Confirmations:          uint64(getEnvAsInt("CONFIRMATIONS", 12)),
+		LogChunkSize:           uint64(getEnvAsInt("LOG_CHUNK_SIZE", 2000)),
+		PollInterval:           getEnvAsInt("POLL_INTERVAL", 15),
+		IndexMode:              getEnv("INDEX_MODE", "events"),
+		StateSyncInterval:      getEnvAsInt("STATE_SYNC_INTERVAL", 60),
+		ReconcileInterval:      getEnvAsInt("RECONCILE_INTERVAL", 300),
+		ImplementationABI:      getEnv("IMPLEMENTATION_ABI", ""),
+		FeeStrategy:            getEnv("FEE_STRATEGY", "standard"),
+		MaxFeePerGasGwei:       getEnvAsFloat("MAX_FEE_PER_GAS_GWEI", 0),
+		MaxPriorityFeeGwei:     getEnvAsFloat("MAX_PRIORITY_FEE_GWEI", 0),
+		TxDropTimeout:          getEnvAsInt("TX_DROP_TIMEOUT", 1800),
+		PriceFeedMaxAge:        getEnvAsInt("PRICE_FEED_MAX_AGE", 3600),
+		KeeperInterval:         getEnvAsInt("KEEPER_INTERVAL", 0),
+		KeeperLeaseTTL:         getEnvAsInt("KEEPER_LEASE_TTL", 120),
+		KeeperMaxAttempts:      getEnvAsInt("KEEPER_MAX_ATTEMPTS", 5),
+		KeeperRetryDelay:       getEnvAsInt("KEEPER_RETRY_DELAY", 60),
+		KeeperBumpAfter:        getEnvAsInt("KEEPER_BUMP_AFTER", 180),
+		KeeperBumpPercent:      int64(getEnvAsInt("KEEPER_BUMP_PERCENT", 125)),
+		SignerType:             getEnv("SIGNER_TYPE", "none"),
+		SignerKeystorePath:     getEnv("SIGNER_KEYSTORE_PATH", ""),
+		SignerKeystorePassword: getEnv("SIGNER_KEYSTORE_PASSWORD", ""),
+		SignerAddress:          getEnv("SIGNER_ADDRESS", ""),
+		SignerRemoteURL:        getEnv("SIGNER_REMOTE_URL", ""),
+		SignerRemoteKeyID:      getEnv("SIGNER_REMOTE_KEY_ID", ""),
+		SignerRemoteToken:      getEnv("SIGNER_REMOTE_TOKEN", ""),
+		SignerLocalKey:         getEnv("SIGNER_LOCAL_KEY", ""),
+		ServerPort:             getEnv("SERVER_PORT", "8080"),
+		AdminToken:             getEnv("ADMIN_TOKEN", ""),
+		AlchemyAPIKey:          getEnv("ALCHEMY_API_KEY", ""),
+		AlchemyBaseURL:         getEnv("ALCHEMY_BASE_URL", "https://eth-mainnet.g.alchemy.com/nft/v3"),
+		OpenSeaAPIKey:          getEnv("OPENSEA_API_KEY", ""),
 	}
 
 	// 验证必需的配置
 	if AppConfig.ETHRPCURL == "" {
 		return fmt.Errorf("ETH_RPC_URL is required")
 	}
+	chainRPCURLs, err := parseChainURLs("CHAIN_RPC_URLS")
+	if err != nil {
+		return err
+	}
+	AppConfig.ChainRPCURLs = chainRPCURLs
+	alchemyChainURLs, err := parseChainURLs("ALCHEMY_CHAIN_URLS")
+	if err != nil {
+		return err
+	}
+	AppConfig.AlchemyChainURLs = make(map[uint64]string, len(alchemyChainURLs))
+	for chainID, urls := range alchemyChainURLs {
+		if len(urls) > 1 {
+			return fmt.Errorf("ALCHEMY_CHAIN_URLS lists chain %d more than once", chainID)
+		}
+		AppConfig.AlchemyChainURLs[chainID] = urls[0]
+	}
+	if AppConfig.PollInterval <= 0 {
+		return fmt.Errorf("POLL_INTERVAL must be positive")
+	}
+	if AppConfig.RPCProbeInterval <= 0 {
+		return fmt.Errorf("RPC_PROBE_INTERVAL must be positive")
+	}
 	if AppConfig.ContractAddress == "" {
 		return fmt.Errorf("CONTRACT_ADDRESS is required")
 	}
+	switch AppConfig.IndexMode {
+	case "events", "calls", "state":
+	default:
+		return fmt.Errorf("INDEX_MODE must be one of events, calls, state")
+	}
+	switch AppConfig.FeeStrategy {
+	case "slow", "standard", "fast":
+	default:
+		return fmt.Errorf("FEE_STRATEGY must be one of slow, standard, fast")
+	}
+	if AppConfig.KeeperInterval > 0 && AppConfig.KeeperLeaseTTL <= AppConfig.KeeperInterval {
+		return fmt.Errorf("KEEPER_LEASE_TTL must be greater than KEEPER_INTERVAL")
+	}
+	if AppConfig.KeeperBumpPercent < 110 {
+		return fmt.Errorf("KEEPER_BUMP_PERCENT must be at least 110")
+	}
 
 	return nil
 }
@@ -90,6 +200,41 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+// parseChainURLs 解析按链配置地址的环境变量，格式为 链ID=地址，多个以逗号分隔，同一条链可出现多次
+func parseChainURLs(key string) (map[uint64][]string, error) {
+	urls := make(map[uint64][]string)
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, url, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(url) == "" {
+			return nil, fmt.Errorf("%s entry %q must be chainID=url", key, entry)
+		}
+		chainID, err := strconv.ParseUint(strings.TrimSpace(id), 10, 64)
+		if err != nil || chainID == 0 {
+			return nil, fmt.Errorf("%s entry %q has invalid chain ID", key, entry)
+		}
+		urls[chainID] = append(urls[chainID], strings.TrimSpace(url))
+	}
+	return urls, nil
+}
+
+// getEnvAsFloat 获取浮点类型的环境变量
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	var value float64
+	_, err := fmt.Sscanf(valueStr, "%g", &value)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // GetDSN 获取数据库连接字符串
 func (c *Config) GetDSN() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
@@ -23,7 +23,10 @@ func InitDB(dsn string) error {
 	}
 
 	// 自动迁移数据库表
-	if err := DB.AutoMigrate(&models.Auction{}, &models.Bid{}, &models.NFTMetadata{}, &models.NFTCollection{}); err != nil {
+	if err := DB.AutoMigrate(&models.Auction{}, &models.Bid{}, &models.NFTMetadata{}, &models.NFTCollection{}, &models.SyncCursor{}, &models.IndexedBlock{}, &models.Event{}, &models.DeadLetter{}, &models.ReconcileReport{}, &models.AuctionDiscrepancy{}, &models.Transaction{}, &models.PriceFeed{}, &models.KeeperJob{}, &models.Lease{}, &models.Implementation{}, &models.Contract{}); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	if err := dropLegacyIndexes(); err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
@@ -31,6 +34,36 @@ func InitDB(dsn string) error {
 	return nil
 }
 
+// dropLegacyIndexes 删除单合约、单链时期建立的索引，不同合约或不同链上的拍卖ID和 NFT 合约地址可以重复
+func dropLegacyIndexes() error {
+	legacy := []struct {
+		model interface{}
+		names []string
+	}{
+		{&models.Auction{}, []string{"idx_auctions_auction_id", "auction_id", "idx_auction_identity"}},
+		{&models.Bid{}, []string{"idx_bid_auction", "idx_bid_event"}},
+		{&models.Event{}, []string{"idx_event_identity"}},
+		{&models.DeadLetter{}, []string{"idx_dead_letter_identity"}},
+		{&models.KeeperJob{}, []string{"idx_keeper_jobs_auction_id", "idx_keeper_job_auction"}},
+		{&models.NFTCollection{}, []string{"idx_nft_collections_contract"}},
+		{&models.PriceFeed{}, []string{"idx_price_feeds_token_address", "idx_price_feed_token"}},
+	}
+
+	migrator := DB.Migrator()
+	for _, table := range legacy {
+		for _, name := range table.names {
+			if !migrator.HasIndex(table.model, name) {
+				continue
+			}
+			if err := migrator.DropIndex(table.model, name); err != nil {
+				return fmt.Errorf("failed to drop index %s: %w", name, err)
+			}
+			log.Printf("Dropped legacy index %s\n", name)
+		}
+	}
+	return nil
+}
+
 // GetDB 获取数据库实例
 func GetDB() *gorm.DB {
 	return DB
@@ -0,0 +1,813 @@
+package handlers
+
+import (
+	"auction-backend/blockchain"
+	"auction-backend/config"
+	"auction-backend/database"
+	"auction-backend/models"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DeadLetterListResponse 死信列表响应
+type DeadLetterListResponse struct {
+	Total       int64               `json:"total"`
+	Page        int                 `json:"page"`
+	PageSize    int                 `json:"page_size"`
+	DeadLetters []models.DeadLetter `json:"dead_letters"`
+}
+
+// ReconcileReportListResponse 对账报告列表响应
+type ReconcileReportListResponse struct {
+	Total    int64                    `json:"total"`
+	Page     int                      `json:"page"`
+	PageSize int                      `json:"page_size"`
+	Reports  []models.ReconcileReport `json:"reports"`
+}
+
+// AdminAuth 管理接口鉴权，要求请求头 Authorization: Bearer <ADMIN_TOKEN>
+func AdminAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config.AppConfig.AdminToken == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "Admin API is disabled",
+			})
+			return
+		}
+
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(config.AppConfig.AdminToken)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid admin token",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ListDeadLetters 获取死信列表
+// GET /api/admin/dead-letters?chain=1&status=pending&page=1&page_size=10
+func ListDeadLetters(c *gin.Context) {
+	status := c.DefaultQuery("status", models.DeadLetterPending) // pending, resolved, discarded, all
+	chain, ok := chainFilter(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	db := database.GetDB()
+	query := db.Model(&models.DeadLetter{}).Scopes(chain)
+	if status != "all" {
+		query = query.Where("status = ?", status)
+	}
+
+	// 获取总数
+	var total int64
+	query.Count(&total)
+
+	// 分页查询
+	var letters []models.DeadLetter
+	offset := (page - 1) * pageSize
+	if err := query.Order("block_number ASC, log_index ASC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&letters).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query dead letters",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, DeadLetterListResponse{
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		DeadLetters: letters,
+	})
+}
+
+// GetDeadLetter 获取死信详情
+// GET /api/admin/dead-letters/:id
+func GetDeadLetter(c *gin.Context) {
+	db := database.GetDB()
+	var letter models.DeadLetter
+	if err := db.First(&letter, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Dead letter not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, letter)
+}
+
+// RetryDeadLetter 重新处理死信
+// POST /api/admin/dead-letters/:id/retry
+func RetryDeadLetter(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid dead letter ID",
+		})
+		return
+	}
+
+	letter, err := blockchain.RetryDeadLetter(uint(id))
+	if err != nil {
+		respondDeadLetterError(c, "Failed to retry dead letter: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Dead letter processed successfully",
+		"dead_letter": letter,
+	})
+}
+
+// DiscardDeadLetter 丢弃死信
+// POST /api/admin/dead-letters/:id/discard
+func DiscardDeadLetter(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid dead letter ID",
+		})
+		return
+	}
+
+	letter, err := blockchain.DiscardDeadLetter(uint(id))
+	if err != nil {
+		respondDeadLetterError(c, "Failed to discard dead letter: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Dead letter discarded",
+		"dead_letter": letter,
+	})
+}
+
+// respondDeadLetterError 根据错误类型返回对应的状态码
+func respondDeadLetterError(c *gin.Context, prefix string, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Dead letter not found",
+		})
+	case errors.Is(err, blockchain.ErrDeadLetterClosed):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": prefix + err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": prefix + err.Error(),
+		})
+	}
+}
+
+// ListReconcileReports 获取对账报告列表
+// GET /api/admin/reconcile/reports?chain=1&contract=0x...&mismatched=true&page=1&page_size=10
+func ListReconcileReports(c *gin.Context) {
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	db := database.GetDB()
+	query := db.Model(&models.ReconcileReport{}).Scopes(contractScope(contract))
+	// 只看发现差异的报告
+	if c.Query("mismatched") == "true" {
+		query = query.Where("mismatched > ?", 0)
+	}
+
+	// 获取总数
+	var total int64
+	query.Count(&total)
+
+	// 分页查询
+	var reports []models.ReconcileReport
+	offset := (page - 1) * pageSize
+	if err := query.Order("started_at DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&reports).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query reconcile reports",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReconcileReportListResponse{
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		Reports:  reports,
+	})
+}
+
+// GetReconcileReport 获取对账报告详情（含差异明细）
+// GET /api/admin/reconcile/reports/:id
+func GetReconcileReport(c *gin.Context) {
+	db := database.GetDB()
+	var report models.ReconcileReport
+	if err := db.Preload("Discrepancies").First(&report, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Reconcile report not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// RunReconcile 立即执行一次链上对账
+// POST /api/admin/reconcile?chain=1&contract=0x...
+func RunReconcile(c *gin.Context) {
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
+	reconciler := blockchain.GetReconciler(contract.chainID, contract.address)
+	if reconciler == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Reconciler is not running",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	report, err := reconciler.Reconcile(ctx, blockchain.ReconcileManual)
+	if errors.Is(err, blockchain.ErrReconcileRunning) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Reconciliation already running",
+		})
+		return
+	}
+	if report == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reconcile auctions: " + err.Error(),
+		})
+		return
+	}
+
+	// 对账中途失败时报告仍会保存，错误信息记录在报告中
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reconciliation finished",
+		"report":  report,
+	})
+}
+
+// CreateAuctionRequest 创建拍卖请求
+type CreateAuctionRequest struct {
+	NFTContract string `json:"nft_contract" binding:"required"` // NFT 合约地址
+	TokenID     string `json:"token_id" binding:"required"`     // NFT Token ID
+	StartPrice  string `json:"start_price" binding:"required"`  // 起拍价（wei）
+	Duration    uint64 `json:"duration" binding:"required"`     // 拍卖时长（秒）
+	Category    string `json:"category"`                        // 分类
+}
+
+// CreateAuction 以服务端签名账户创建拍卖，NFT 需属于该账户并已授权给拍卖合约
+// POST /api/admin/auctions?chain=1&contract=0x...
+func CreateAuction(c *gin.Context) {
+	var req CreateAuctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	if !common.IsHexAddress(req.NFTContract) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid NFT contract address",
+		})
+		return
+	}
+	tokenID, ok := new(big.Int).SetString(req.TokenID, 10)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid token ID",
+		})
+		return
+	}
+	startPrice, ok := new(big.Int).SetString(req.StartPrice, 10)
+	if !ok || startPrice.Sign() <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid start price",
+		})
+		return
+	}
+	// 合约要求拍卖时长不少于 10 秒
+	if req.Duration < 10 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Duration must be at least 10 seconds",
+		})
+		return
+	}
+	if len(req.Category) > 50 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Category is too long",
+		})
+		return
+	}
+
+	contractService, ok := scopedContractService(c)
+	if !ok {
+		return
+	}
+
+	// 包含等待交易打包的时间
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	auction, tx, err := contractService.CreateAuction(ctx, blockchain.CreateAuctionParams{
+		Duration:    new(big.Int).SetUint64(req.Duration),
+		StartPrice:  startPrice,
+		NFTContract: common.HexToAddress(req.NFTContract),
+		TokenID:     tokenID,
+		Category:    req.Category,
+	})
+	switch {
+	case errors.Is(err, blockchain.ErrNoSigner):
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case errors.Is(err, blockchain.ErrNotAdmin), errors.Is(err, blockchain.ErrNFTNotReady):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case err != nil && tx != nil:
+		// 交易已广播，结果可通过交易状态接口查询，打包后由监听器索引
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Transaction submitted but auction was not indexed: " + err.Error(),
+			"tx_hash": tx.Hash().Hex(),
+		})
+		return
+	case err != nil:
+		respondTxError(c, "Failed to create auction: ", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Auction created",
+		"tx_hash": tx.Hash().Hex(),
+		"auction": auction,
+	})
+}
+
+// ListPriceFeeds 获取已知代币的预言机状态
+// GET /api/admin/price-feeds?chain=1&contract=0x...
+func ListPriceFeeds(c *gin.Context) {
+	contractService, ok := scopedContractService(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	feeds, err := contractService.ListPriceFeeds(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to read price feeds: " + err.Error(),
+		})
+		return
+	}
+
+	// 会导致出价 revert 的预言机单独列出
+	blocking := make([]string, 0)
+	for _, feed := range feeds {
+		if feed.BlocksBids() {
+			blocking = append(blocking, feed.TokenAddress)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"feeds":           feeds,
+		"blocking_tokens": blocking,
+	})
+}
+
+// GetPriceFeed 获取代币的预言机状态
+// GET /api/admin/price-feeds/:token?chain=1&contract=0x...
+func GetPriceFeed(c *gin.Context) {
+	token := c.Param("token")
+	if !common.IsHexAddress(token) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid token address",
+		})
+		return
+	}
+
+	contractService, ok := scopedContractService(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	feed, err := contractService.FeedStatus(ctx, common.HexToAddress(token))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to read price feed: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// SetPriceFeedRequest 设置预言机请求
+type SetPriceFeedRequest struct {
+	TokenAddress string `json:"token_address" binding:"required"` // 代币地址，0x0000000000000000000000000000000000000000 表示ETH
+	FeedAddress  string `json:"feed_address" binding:"required"`  // Chainlink 预言机地址
+}
+
+// SetPriceFeed 以服务端签名账户调用 setPriceFeed
+// POST /api/admin/price-feeds?chain=1&contract=0x...
+func SetPriceFeed(c *gin.Context) {
+	var req SetPriceFeedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	if !common.IsHexAddress(req.TokenAddress) || !common.IsHexAddress(req.FeedAddress) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid token or feed address",
+		})
+		return
+	}
+
+	contractService, ok := scopedContractService(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, feed, err := contractService.SetPriceFeed(ctx, common.HexToAddress(req.TokenAddress), common.HexToAddress(req.FeedAddress))
+	switch {
+	case errors.Is(err, blockchain.ErrNoSigner):
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case errors.Is(err, blockchain.ErrInvalidPriceFeed):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+			"feed":  feed,
+		})
+		return
+	case err != nil:
+		respondTxError(c, "Failed to set price feed: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Price feed transaction submitted",
+		"tx_hash": tx.Hash().Hex(),
+		"feed":    feed,
+	})
+}
+
+// KeeperJobListResponse 自动结束拍卖任务列表响应
+type KeeperJobListResponse struct {
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+	Jobs     []models.KeeperJob `json:"jobs"`
+}
+
+// ListKeeperJobs 获取自动结束拍卖任务列表
+// GET /api/admin/keeper/jobs?chain=1&contract=0x...&status=failed&page=1&page_size=10
+func ListKeeperJobs(c *gin.Context) {
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
+	status := c.DefaultQuery("status", "all") // pending, submitted, succeeded, failed, reverted, skipped, all
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	db := database.GetDB()
+	query := db.Model(&models.KeeperJob{}).Scopes(contractScope(contract))
+	if status != "all" {
+		query = query.Where("status = ?", status)
+	}
+
+	// 获取总数
+	var total int64
+	query.Count(&total)
+
+	// 分页查询
+	var jobs []models.KeeperJob
+	offset := (page - 1) * pageSize
+	if err := query.Order("updated_at DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&jobs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query keeper jobs",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, KeeperJobListResponse{
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		Jobs:     jobs,
+	})
+}
+
+// RetryKeeperJob 重新提交已结束的自动结束拍卖任务
+// POST /api/admin/keeper/jobs/:id/retry
+func RetryKeeperJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid keeper job ID",
+		})
+		return
+	}
+
+	job, err := blockchain.RetryKeeperJob(uint(id))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Keeper job not found",
+		})
+		return
+	case errors.Is(err, blockchain.ErrKeeperJobActive):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retry keeper job: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Keeper job rescheduled",
+		"job":     job,
+	})
+}
+
+// ListImplementations 获取代理合约的实现历史，每条记录对应一个使用同一 ABI 版本解码的区块区间
+// GET /api/admin/implementations?chain=1&proxy=0x...
+func ListImplementations(c *gin.Context) {
+	chain, ok := chainFilter(c)
+	if !ok {
+		return
+	}
+	query := database.GetDB().Model(&models.Implementation{}).Scopes(chain)
+	if proxy := c.Query("proxy"); proxy != "" {
+		if !common.IsHexAddress(proxy) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid proxy address",
+			})
+			return
+		}
+		query = query.Where("proxy_address = ?", strings.ToLower(proxy))
+	}
+
+	var implementations []models.Implementation
+	if err := query.Order("chain_id ASC, proxy_address ASC, from_block ASC").
+		Find(&implementations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query implementations",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"implementations": implementations,
+	})
+}
+
+// ContractStatus 注册表中的合约及其监听器状态
+type ContractStatus struct {
+	models.Contract
+	Indexer *blockchain.SupervisorStatus `json:"indexer"` // 未在索引时为 nil
+}
+
+// ListContracts 获取拍卖合约注册表
+// GET /api/admin/contracts?chain=1
+func ListContracts(c *gin.Context) {
+	chain, ok := chainFilter(c)
+	if !ok {
+		return
+	}
+	var contracts []models.Contract
+	if err := database.GetDB().Scopes(chain).Order("id ASC").Find(&contracts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query contracts",
+		})
+		return
+	}
+
+	statuses := make([]ContractStatus, 0, len(contracts))
+	for _, contract := range contracts {
+		status := ContractStatus{Contract: contract}
+		if supervisor := blockchain.GetSupervisor(contract.ChainID, contract.Address); supervisor != nil {
+			indexer := supervisor.Status()
+			status.Indexer = &indexer
+		}
+		statuses = append(statuses, status)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"contracts": statuses,
+	})
+}
+
+// RegisterContractRequest 注册拍卖合约请求
+type RegisterContractRequest struct {
+	Address    string `json:"address" binding:"required"` // 拍卖合约（代理）地址
+	Name       string `json:"name"`                       // 部署名称，如 test、staging
+	StartBlock uint64 `json:"start_block"`                // 首次同步的起始区块
+	ABIVersion string `json:"abi_version"`                // 固定使用的 ABI 版本（v1、v2），为空时按实现历史选择
+}
+
+// RegisterContract 注册拍卖合约并立即开始索引
+// POST /api/admin/contracts?chain=1
+func RegisterContract(c *gin.Context) {
+	var req RegisterContractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	if !common.IsHexAddress(req.Address) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid contract address",
+		})
+		return
+	}
+	if len(req.Name) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Name is too long",
+		})
+		return
+	}
+	chainID, ok := chainParam(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	contract, err := blockchain.RegisterContract(ctx, blockchain.RegisterContractParams{
+		ChainID:    chainID,
+		Address:    common.HexToAddress(req.Address),
+		Name:       req.Name,
+		StartBlock: req.StartBlock,
+		ABIVersion: req.ABIVersion,
+	})
+	switch {
+	case errors.Is(err, blockchain.ErrUnknownABIVersion), errors.Is(err, blockchain.ErrNoContractCode):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case errors.Is(err, blockchain.ErrContractExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to register contract: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Contract registered",
+		"contract": contract,
+	})
+}
+
+// UpdateContractRequest 修改拍卖合约请求，未提供的字段不修改
+type UpdateContractRequest struct {
+	Name       *string `json:"name"`
+	Enabled    *bool   `json:"enabled"`     // false 停止索引，已索引的数据保留
+	ABIVersion *string `json:"abi_version"` // 空字符串表示按实现历史选择
+}
+
+// UpdateContract 修改拍卖合约，启用的合约会重启监听器使新配置生效
+// PUT /api/admin/contracts/:id
+func UpdateContract(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid contract ID",
+		})
+		return
+	}
+
+	var req UpdateContractRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
+		})
+		return
+	}
+	if req.Name != nil && len(*req.Name) > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Name is too long",
+		})
+		return
+	}
+
+	contract, err := blockchain.UpdateContract(uint(id), blockchain.ContractUpdate{
+		Name:       req.Name,
+		Enabled:    req.Enabled,
+		ABIVersion: req.ABIVersion,
+	})
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Contract not found",
+		})
+		return
+	case errors.Is(err, blockchain.ErrUnknownABIVersion):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	case errors.Is(err, blockchain.ErrStopTimeout):
+		// 修改已保存，旧监听器仍在退出，稍后重新提交即可按新配置启动
+		c.JSON(http.StatusConflict, gin.H{
+			"error":    "Contract updated but the running indexer has not stopped yet, retry later",
+			"contract": contract,
+		})
+		return
+	case err != nil && contract != nil:
+		// 修改已保存，但监听器未能启动
+		c.JSON(http.StatusAccepted, gin.H{
+			"message":  "Contract updated but indexing failed to start: " + err.Error(),
+			"contract": contract,
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to update contract: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Contract updated",
+		"contract": contract,
+	})
+}
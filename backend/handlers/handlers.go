@@ -7,7 +7,9 @@ import (
 	"auction-backend/models"
 	"auction-backend/services"
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
 	"net/http"
 	"strconv"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // AuctionListResponse 拍卖列表响应
@@ -34,9 +37,141 @@ type BidListResponse struct {
 	Bids     []models.Bid `json:"bids"`
 }
 
+// finalityScope 按确认状态过滤: confirmed 只返回已确认数据，pending 只返回未确认数据，其他值不过滤
+func finalityScope(finality string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch finality {
+		case "confirmed":
+			return db.Where("confirmed = ?", true)
+		case "pending":
+			return db.Where("confirmed = ?", false)
+		default:
+			return db
+		}
+	}
+}
+
+// chainParam 读取 chain 查询参数指定的链ID，未指定时使用默认链（ETH_RPC_URL 所在的链）
+// 链ID无效或未配置 RPC 地址时直接返回 400
+func chainParam(c *gin.Context) (uint64, bool) {
+	value := c.Query("chain")
+	if value == "" {
+		return blockchain.DefaultChainID(), true
+	}
+	chainID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || !blockchain.IsKnownChain(chainID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Unsupported chain",
+		})
+		return 0, false
+	}
+	return chainID, true
+}
+
+// chainFilter 读取 chain 查询参数，指定时按链过滤，未指定时不过滤
+func chainFilter(c *gin.Context) (func(*gorm.DB) *gorm.DB, bool) {
+	if c.Query("chain") == "" {
+		return func(db *gorm.DB) *gorm.DB { return db }, true
+	}
+	chainID, ok := chainParam(c)
+	if !ok {
+		return nil, false
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("chain_id = ?", chainID)
+	}, true
+}
+
+// contractTarget chain 和 contract 查询参数指定的拍卖合约
+type contractTarget struct {
+	chainID uint64
+	address string // 小写地址
+}
+
+// contractParam 读取 chain 和 contract 查询参数指定的拍卖合约，未指定合约时使用该链的默认合约
+// 地址无效时直接返回 400，链上没有注册合约时返回 404
+func contractParam(c *gin.Context) (contractTarget, bool) {
+	chainID, ok := chainParam(c)
+	if !ok {
+		return contractTarget{}, false
+	}
+
+	address := c.Query("contract")
+	if address == "" {
+		key, err := blockchain.ChainDefaultContract(chainID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "No contract registered on chain",
+			})
+			return contractTarget{}, false
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to find contract: " + err.Error(),
+			})
+			return contractTarget{}, false
+		}
+		return contractTarget{chainID: chainID, address: key}, true
+	}
+	if !common.IsHexAddress(address) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid contract address",
+		})
+		return contractTarget{}, false
+	}
+	return contractTarget{chainID: chainID, address: strings.ToLower(address)}, true
+}
+
+// contractScope 按链和拍卖合约过滤，不同合约或不同链上的拍卖ID可能重复
+func contractScope(contract contractTarget) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("chain_id = ? AND contract_address = ?", contract.chainID, contract.address)
+	}
+}
+
+// scopedContractService 创建 chain 和 contract 查询参数指定合约的服务实例，合约必须已在注册表中
+func scopedContractService(c *gin.Context) (*blockchain.ContractService, bool) {
+	contract, ok := contractParam(c)
+	if !ok {
+		return nil, false
+	}
+	if _, err := blockchain.LookupContract(contract.chainID, contract.address); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Contract not registered",
+			})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to find contract: " + err.Error(),
+		})
+		return nil, false
+	}
+
+	pool, err := blockchain.GetRPCPool(contract.chainID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Unsupported chain",
+		})
+		return nil, false
+	}
+	contractService, err := blockchain.NewContractServiceAt(pool, common.HexToAddress(contract.address))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to connect to blockchain: " + err.Error(),
+		})
+		return nil, false
+	}
+	return contractService, true
+}
+
 // GetAuctionList 获取拍卖列表
-// GET /api/auctions?page=1&page_size=10&status=active&seller=0x...&sort_by=price&order=desc&category=art
+// GET /api/auctions?chain=1&contract=0x...&page=1&page_size=10&status=active&seller=0x...&sort_by=price&order=desc&category=art&finality=confirmed
 func GetAuctionList(c *gin.Context) {
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 	status := c.Query("status")                       // active, ended, all
@@ -45,6 +180,7 @@ func GetAuctionList(c *gin.Context) {
 	category := c.Query("category")                   // 分类
 	sortBy := c.DefaultQuery("sort_by", "start_time") // 排序字段: start_time, highest_bid, bid_count
 	order := c.DefaultQuery("order", "desc")          // 排序顺序: asc, desc
+	finality := c.Query("finality")                   // 确认状态: confirmed, pending, all
 
 	if page < 1 {
 		page = 1
@@ -54,7 +190,7 @@ func GetAuctionList(c *gin.Context) {
 	}
 
 	db := database.GetDB()
-	query := db.Model(&models.Auction{})
+	query := db.Model(&models.Auction{}).Scopes(contractScope(contract), finalityScope(finality))
 
 	// 过滤条件
 	if status == "active" {
@@ -120,13 +256,17 @@ func GetAuctionList(c *gin.Context) {
 }
 
 // GetAuctionDetail 获取拍卖详情
-// GET /api/auctions/:id
+// GET /api/auctions/:id?chain=1&contract=0x...
 func GetAuctionDetail(c *gin.Context) {
 	auctionID := c.Param("id")
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 
 	db := database.GetDB()
 	var auction models.Auction
-	if err := db.Where("auction_id = ?", auctionID).First(&auction).Error; err != nil {
+	if err := db.Scopes(contractScope(contract)).Where("auction_id = ?", auctionID).First(&auction).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Auction not found",
 		})
@@ -137,9 +277,13 @@ func GetAuctionDetail(c *gin.Context) {
 }
 
 // GetAuctionBids 获取拍卖的出价历史
-// GET /api/auctions/:id/bids?page=1&page_size=10
+// GET /api/auctions/:id/bids?chain=1&contract=0x...&page=1&page_size=10
 func GetAuctionBids(c *gin.Context) {
 	auctionID := c.Param("id")
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 
@@ -151,7 +295,7 @@ func GetAuctionBids(c *gin.Context) {
 	}
 
 	db := database.GetDB()
-	query := db.Model(&models.Bid{}).Where("auction_id = ?", auctionID)
+	query := db.Model(&models.Bid{}).Scopes(contractScope(contract)).Where("auction_id = ?", auctionID)
 
 	// 获取总数
 	var total int64
@@ -179,7 +323,7 @@ func GetAuctionBids(c *gin.Context) {
 }
 
 // GetBidsByBidder 获取某个地址的所有出价记录
-// GET /api/bids?bidder=0x...&page=1&page_size=10
+// GET /api/bids?bidder=0x...&chain=1&contract=0x...&page=1&page_size=10
 func GetBidsByBidder(c *gin.Context) {
 	bidder := c.Query("bidder")
 	if bidder == "" {
@@ -188,6 +332,10 @@ func GetBidsByBidder(c *gin.Context) {
 		})
 		return
 	}
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
@@ -200,7 +348,7 @@ func GetBidsByBidder(c *gin.Context) {
 	}
 
 	db := database.GetDB()
-	query := db.Model(&models.Bid{}).Where("bidder = ?", bidder)
+	query := db.Model(&models.Bid{}).Scopes(contractScope(contract)).Where("bidder = ?", bidder)
 
 	// 获取总数
 	var total int64
@@ -228,19 +376,24 @@ func GetBidsByBidder(c *gin.Context) {
 }
 
 // GetStats 获取统计信息
-// GET /api/stats
+// GET /api/stats?chain=1&contract=0x...&finality=confirmed
 func GetStats(c *gin.Context) {
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 	db := database.GetDB()
+	scope := finalityScope(c.Query("finality"))
 
 	var totalAuctions int64
 	var activeAuctions int64
 	var endedAuctions int64
 	var totalBids int64
 
-	db.Model(&models.Auction{}).Count(&totalAuctions)
-	db.Model(&models.Auction{}).Where("ended = ?", false).Count(&activeAuctions)
-	db.Model(&models.Auction{}).Where("ended = ?", true).Count(&endedAuctions)
-	db.Model(&models.Bid{}).Count(&totalBids)
+	db.Model(&models.Auction{}).Scopes(contractScope(contract), scope).Count(&totalAuctions)
+	db.Model(&models.Auction{}).Scopes(contractScope(contract), scope).Where("ended = ?", false).Count(&activeAuctions)
+	db.Model(&models.Auction{}).Scopes(contractScope(contract), scope).Where("ended = ?", true).Count(&endedAuctions)
+	db.Model(&models.Bid{}).Scopes(contractScope(contract), scope).Count(&totalBids)
 
 	c.JSON(http.StatusOK, gin.H{
 		"total_auctions":  totalAuctions,
@@ -250,11 +403,44 @@ func GetStats(c *gin.Context) {
 	})
 }
 
-// HealthCheck 健康检查
+// HealthCheck 健康检查，indexer 为默认合约的监听器状态，indexers 包含所有正在索引的合约，键为 链ID:合约地址
+// rpc 为各链 RPC 节点池中每个节点的延迟、错误率和区块落后情况
 // GET /health
 func HealthCheck(c *gin.Context) {
+	status := "ok"
+	indexers := make(map[string]blockchain.SupervisorStatus)
+	for key, supervisor := range blockchain.Supervisors() {
+		indexers[key] = supervisor.Status()
+		if indexers[key].State != blockchain.StateRunning {
+			status = "degraded"
+		}
+	}
+
+	// 任一条链没有健康的 RPC 节点时视为降级
+	rpc := make(map[uint64][]blockchain.EndpointStatus)
+	for chainID, pool := range blockchain.RPCPools() {
+		rpc[chainID] = pool.Status()
+		if !pool.Healthy() {
+			status = "degraded"
+		}
+	}
+
+	indexer, ok := indexers[blockchain.IndexerKey(blockchain.DefaultChainID(), blockchain.DefaultContractKey())]
+	if !ok {
+		c.JSON(http.StatusOK, gin.H{
+			"status":   "degraded",
+			"indexer":  gin.H{"state": "not_started"},
+			"indexers": indexers,
+			"rpc":      rpc,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status": "ok",
+		"status":   status,
+		"indexer":  indexer,
+		"indexers": indexers,
+		"rpc":      rpc,
 	})
 }
 
@@ -263,13 +449,17 @@ type PlaceBidRequest struct {
 	AuctionID    string `json:"auction_id" binding:"required"`  // 拍卖ID
 	Amount       string `json:"amount" binding:"required"`      // 出价金额（wei）
 	TokenAddress string `json:"token_address"`                  // 代币地址，空或0x0表示ETH
-	PrivateKey   string `json:"private_key" binding:"required"` // 用户私钥
+	From         string `json:"from" binding:"required"`        // 出价人钱包地址
 }
 
-// PlaceBid 参与出价
-// POST /api/auctions/:id/bid
+// PlaceBid 构造出价交易，由用户钱包签名后通过 /api/tx/broadcast 广播
+// POST /api/auctions/:id/bid?chain=1&contract=0x...
 func PlaceBid(c *gin.Context) {
 	auctionID := c.Param("id")
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 
 	var req PlaceBidRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -282,7 +472,7 @@ func PlaceBid(c *gin.Context) {
 	// 验证拍卖是否存在且未结束
 	db := database.GetDB()
 	var auction models.Auction
-	if err := db.Where("auction_id = ?", auctionID).First(&auction).Error; err != nil {
+	if err := db.Scopes(contractScope(contract)).Where("auction_id = ?", auctionID).First(&auction).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Auction not found",
 		})
@@ -323,6 +513,15 @@ func PlaceBid(c *gin.Context) {
 		return
 	}
 
+	// 解析出价人地址
+	if !common.IsHexAddress(req.From) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid from address",
+		})
+		return
+	}
+	from := common.HexToAddress(req.From)
+
 	// 处理代币地址
 	var tokenAddress common.Address
 	if req.TokenAddress == "" || req.TokenAddress == "0x0" || req.TokenAddress == "0x0000000000000000000000000000000000000000" {
@@ -332,47 +531,60 @@ func PlaceBid(c *gin.Context) {
 	}
 
 	// 创建合约服务
-	contractService, err := blockchain.NewContractService()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to connect to blockchain: " + err.Error(),
-		})
+	contractService, ok := scopedContractService(c)
+	if !ok {
 		return
 	}
 
-	// 调用合约
+	// 构造交易
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	tx, err := contractService.PlaceBid(ctx, blockchain.PlaceBidRequest{
-		AuctionID:    auctionIDInt,
-		Amount:       amount,
-		TokenAddress: tokenAddress,
-		PrivateKey:   req.PrivateKey,
-	})
-
+	plan, err := contractService.PlanBid(ctx, from, auctionIDInt, amount, tokenAddress)
+	if errors.Is(err, blockchain.ErrInsufficientBalance) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+			"token": plan.Token,
+		})
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to place bid: " + err.Error(),
+		respondTxError(c, "Failed to build bid transaction: ", err)
+		return
+	}
+
+	// ERC-20 授权不足：先签名广播 approve 交易，打包后再次请求出价
+	if plan.Approval != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"message":     "Token allowance is insufficient, sign and broadcast the approve transaction first, then request the bid again once it is mined",
+			"step":        "approve",
+			"token":       plan.Token,
+			"transaction": plan.Approval,
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Bid placed successfully",
-		"tx_hash": tx.Hash().Hex(),
+		"message":     "Transaction built, sign it with your wallet and broadcast via /api/tx/broadcast",
+		"step":        "bid",
+		"token":       plan.Token,
+		"transaction": plan.Bid,
 	})
 }
 
 // EndAuctionRequest 结束拍卖请求
 type EndAuctionRequest struct {
-	PrivateKey string `json:"private_key" binding:"required"` // 调用者私钥
+	From string `json:"from" binding:"required"` // 调用者钱包地址
 }
 
-// EndAuction 结束拍卖
-// POST /api/auctions/:id/end
+// EndAuction 构造结束拍卖交易，由用户钱包签名后通过 /api/tx/broadcast 广播
+// POST /api/auctions/:id/end?chain=1&contract=0x...
 func EndAuction(c *gin.Context) {
 	auctionID := c.Param("id")
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 
 	var req EndAuctionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -385,7 +597,7 @@ func EndAuction(c *gin.Context) {
 	// 验证拍卖是否存在
 	db := database.GetDB()
 	var auction models.Auction
-	if err := db.Where("auction_id = ?", auctionID).First(&auction).Error; err != nil {
+	if err := db.Scopes(contractScope(contract)).Where("auction_id = ?", auctionID).First(&auction).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Auction not found",
 		})
@@ -418,38 +630,126 @@ func EndAuction(c *gin.Context) {
 	}
 
 	// 创建合约服务
-	contractService, err := blockchain.NewContractService()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to connect to blockchain: " + err.Error(),
+	contractService, ok := scopedContractService(c)
+	if !ok {
+		return
+	}
+
+	// 解析调用者地址
+	if !common.IsHexAddress(req.From) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid from address",
 		})
 		return
 	}
 
-	// 调用合约
+	// 构造交易
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	tx, err := contractService.EndAuction(ctx, blockchain.EndAuctionRequest{
-		AuctionID:  auctionIDInt,
-		PrivateKey: req.PrivateKey,
+	tx, err := contractService.BuildEndAuctionTx(ctx, common.HexToAddress(req.From), auctionIDInt)
+	if err != nil {
+		respondTxError(c, "Failed to build end auction transaction: ", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Transaction built, sign it with your wallet and broadcast via /api/tx/broadcast",
+		"transaction": tx,
 	})
+}
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to end auction: " + err.Error(),
+// BroadcastTxRequest 广播签名交易请求
+type BroadcastTxRequest struct {
+	RawTx  string `json:"raw_tx" binding:"required"` // 已签名的交易（hex）
+	Method string `json:"method"`                    // 预期调用的方法（placeBid、endAuction、approve），为空时只校验是否允许
+}
+
+// BroadcastTx 校验并广播用户签名的交易
+// POST /api/tx/broadcast?chain=1&contract=0x...
+func BroadcastTx(c *gin.Context) {
+	var req BroadcastTxRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request: " + err.Error(),
 		})
 		return
 	}
 
+	// 创建合约服务
+	contractService, ok := scopedContractService(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, from, err := contractService.BroadcastRawTx(ctx, req.RawTx, req.Method)
+	if err != nil {
+		respondTxError(c, "Failed to broadcast transaction: ", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Auction ended successfully",
+		"message": "Transaction broadcast successfully",
 		"tx_hash": tx.Hash().Hex(),
+		"from":    from.Hex(),
 	})
 }
 
+// GetTransactionStatus 获取通过后端广播的交易状态
+// GET /api/tx/:hash?chain=1
+func GetTransactionStatus(c *gin.Context) {
+	hash := c.Param("hash")
+	chainID, ok := chainParam(c)
+	if !ok {
+		return
+	}
+
+	db := database.GetDB()
+	var txn models.Transaction
+	if err := db.Where("chain_id = ? AND tx_hash = ?", chainID, common.HexToHash(hash).Hex()).First(&txn).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Transaction not found",
+		})
+		return
+	}
+
+	var confirmations uint64
+	if tracker := blockchain.GetTxTracker(txn.ChainID); tracker != nil {
+		confirmations = tracker.Confirmations(&txn)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction":   txn,
+		"confirmations": confirmations,
+		"finalized":     confirmations > config.AppConfig.Confirmations,
+	})
+}
+
+// respondTxError 根据交易错误类型返回对应的状态码，模拟执行被拒绝时返回解码后的原因
+func respondTxError(c *gin.Context, prefix string, err error) {
+	var revertErr *blockchain.RevertError
+	switch {
+	case errors.As(err, &revertErr):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "Transaction would revert: " + revertErr.Reason,
+			"revert": revertErr,
+		})
+	case errors.Is(err, blockchain.ErrInvalidTransaction):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": prefix + err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": prefix + err.Error(),
+		})
+	}
+}
+
 // GetContractAuctionInfo 从合约读取拍卖信息
-// GET /api/auctions/:id/contract
+// GET /api/auctions/:id/contract?chain=1&contract=0x...
 func GetContractAuctionInfo(c *gin.Context) {
 	auctionID := c.Param("id")
 
@@ -463,11 +763,8 @@ func GetContractAuctionInfo(c *gin.Context) {
 	}
 
 	// 创建合约服务
-	contractService, err := blockchain.NewContractService()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to connect to blockchain: " + err.Error(),
-		})
+	contractService, ok := scopedContractService(c)
+	if !ok {
 		return
 	}
 
@@ -486,8 +783,38 @@ func GetContractAuctionInfo(c *gin.Context) {
 	c.JSON(http.StatusOK, info)
 }
 
+// alchemyServiceFor 创建链的 Alchemy 服务，默认链使用 ALCHEMY_BASE_URL，未配置的链返回 400
+func alchemyServiceFor(c *gin.Context, chainID uint64) (*services.AlchemyService, bool) {
+	if chainID == blockchain.DefaultChainID() {
+		return services.NewAlchemyService(), true
+	}
+	alchemyService, err := services.NewAlchemyServiceForChain(chainID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	return alchemyService, true
+}
+
+// openSeaServiceFor 创建查询链上集合的 OpenSea 服务，OpenSea 不支持的链返回 400
+func openSeaServiceFor(c *gin.Context, chainID uint64) (*services.OpenSeaService, bool) {
+	if chainID == blockchain.DefaultChainID() {
+		return services.NewOpenSeaService(config.AppConfig.OpenSeaAPIKey), true
+	}
+	openseaService, err := services.NewOpenSeaServiceForChain(config.AppConfig.OpenSeaAPIKey, chainID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return nil, false
+	}
+	return openseaService, true
+}
+
 // GetWalletNFTs 获取钱包地址拥有的所有 NFT
-// GET /api/wallet/:address/nfts?page_key=xxx
+// GET /api/wallet/:address/nfts?chain=1&page_key=xxx
 func GetWalletNFTs(c *gin.Context) {
 	address := c.Param("address")
 	pageKey := c.Query("page_key")
@@ -498,9 +825,16 @@ func GetWalletNFTs(c *gin.Context) {
 		})
 		return
 	}
+	chainID, ok := chainParam(c)
+	if !ok {
+		return
+	}
 
 	// 使用 Alchemy API 查询
-	alchemyService := services.NewAlchemyService()
+	alchemyService, ok := alchemyServiceFor(c, chainID)
+	if !ok {
+		return
+	}
 	result, err := alchemyService.GetNFTsByOwner(address, pageKey)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -513,7 +847,7 @@ func GetWalletNFTs(c *gin.Context) {
 }
 
 // GetNFTFloorPrice 获取 NFT 集合地板价
-// GET /api/nft/:contract/floor-price
+// GET /api/nft/:contract/floor-price?chain=1
 func GetNFTFloorPrice(c *gin.Context) {
 	contract := c.Param("contract")
 
@@ -523,14 +857,24 @@ func GetNFTFloorPrice(c *gin.Context) {
 		})
 		return
 	}
+	chainID, ok := chainParam(c)
+	if !ok {
+		return
+	}
 
 	// 优先从数据库查询
 	db := database.GetDB()
 	var collection models.NFTCollection
-	err := db.Where("contract = ?", strings.ToLower(contract)).First(&collection).Error
+	lookupErr := db.Where("chain_id = ? AND contract = ?", chainID, strings.ToLower(contract)).First(&collection).Error
+	if lookupErr != nil && !errors.Is(lookupErr, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query collection: " + lookupErr.Error(),
+		})
+		return
+	}
 
 	// 如果数据库中存在且数据较新（小于1小时），直接返回
-	if err == nil && time.Since(collection.LastSync) < time.Hour {
+	if lookupErr == nil && time.Since(collection.LastSync) < time.Hour {
 		c.JSON(http.StatusOK, gin.H{
 			"contract":     collection.Contract,
 			"floor_price":  collection.FloorPrice,
@@ -542,7 +886,10 @@ func GetNFTFloorPrice(c *gin.Context) {
 	}
 
 	// 从 OpenSea 查询
-	openseaService := services.NewOpenSeaService(config.AppConfig.OpenSeaAPIKey)
+	openseaService, ok := openSeaServiceFor(c, chainID)
+	if !ok {
+		return
+	}
 	floorPrice, err := openseaService.GetFloorPriceByContract(contract)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -551,18 +898,22 @@ func GetNFTFloorPrice(c *gin.Context) {
 		return
 	}
 
-	// 更新数据库
-	if err == nil {
+	// 更新数据库，缓存写入失败不影响本次返回
+	if lookupErr == nil {
 		collection.FloorPrice = fmt.Sprintf("%.18f", floorPrice)
 		collection.LastSync = time.Now()
-		db.Save(&collection)
+		err = db.Save(&collection).Error
 	} else {
 		collection = models.NFTCollection{
+			ChainID:    chainID,
 			Contract:   strings.ToLower(contract),
 			FloorPrice: fmt.Sprintf("%.18f", floorPrice),
 			LastSync:   time.Now(),
 		}
-		db.Create(&collection)
+		err = db.Create(&collection).Error
+	}
+	if err != nil {
+		log.Printf("Failed to cache floor price for %s on chain %d: %v", collection.Contract, chainID, err)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -574,7 +925,7 @@ func GetNFTFloorPrice(c *gin.Context) {
 }
 
 // GetNFTMetadata 获取 NFT 元数据
-// GET /api/nft/:contract/:token_id/metadata
+// GET /api/nft/:contract/:token_id/metadata?chain=1
 func GetNFTMetadata(c *gin.Context) {
 	contract := c.Param("contract")
 	tokenID := c.Param("token_id")
@@ -585,20 +936,34 @@ func GetNFTMetadata(c *gin.Context) {
 		})
 		return
 	}
+	chainID, ok := chainParam(c)
+	if !ok {
+		return
+	}
 
 	// 优先从数据库查询
 	db := database.GetDB()
 	var metadata models.NFTMetadata
-	err := db.Where("contract = ? AND token_id = ?", strings.ToLower(contract), tokenID).First(&metadata).Error
+	lookupErr := db.Where("chain_id = ? AND contract = ? AND token_id = ?", chainID, strings.ToLower(contract), tokenID).
+		First(&metadata).Error
+	if lookupErr != nil && !errors.Is(lookupErr, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query metadata: " + lookupErr.Error(),
+		})
+		return
+	}
 
 	// 如果数据库中存在且数据较新（小于24小时），直接返回
-	if err == nil && time.Since(metadata.LastSync) < 24*time.Hour {
+	if lookupErr == nil && time.Since(metadata.LastSync) < 24*time.Hour {
 		c.JSON(http.StatusOK, metadata)
 		return
 	}
 
 	// 从 Alchemy 查询
-	alchemyService := services.NewAlchemyService()
+	alchemyService, ok := alchemyServiceFor(c, chainID)
+	if !ok {
+		return
+	}
 	newMetadata, err := alchemyService.GetNFTMetadata(contract, tokenID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -606,36 +971,45 @@ func GetNFTMetadata(c *gin.Context) {
 		})
 		return
 	}
+	newMetadata.ChainID = chainID
 
-	// 保存或更新数据库
-	if err == nil {
+	// 保存或更新数据库，缓存写入失败不影响本次返回
+	if lookupErr == nil {
 		newMetadata.ID = metadata.ID
-		db.Save(newMetadata)
+		err = db.Save(newMetadata).Error
 	} else {
-		db.Create(newMetadata)
+		err = db.Create(newMetadata).Error
+	}
+	if err != nil {
+		log.Printf("Failed to cache metadata for %s #%s on chain %d: %v", contract, tokenID, chainID, err)
 	}
 
 	c.JSON(http.StatusOK, newMetadata)
 }
 
 // GetEnhancedStats 获取增强的统计信息（包括 TVL）
-// GET /api/stats/enhanced
+// GET /api/stats/enhanced?chain=1&contract=0x...
 func GetEnhancedStats(c *gin.Context) {
+	contract, ok := contractParam(c)
+	if !ok {
+		return
+	}
 	db := database.GetDB()
+	scope := contractScope(contract)
 
 	var totalAuctions int64
 	var activeAuctions int64
 	var endedAuctions int64
 	var totalBids int64
 
-	db.Model(&models.Auction{}).Count(&totalAuctions)
-	db.Model(&models.Auction{}).Where("ended = ?", false).Count(&activeAuctions)
-	db.Model(&models.Auction{}).Where("ended = ?", true).Count(&endedAuctions)
-	db.Model(&models.Bid{}).Count(&totalBids)
+	db.Model(&models.Auction{}).Scopes(scope).Count(&totalAuctions)
+	db.Model(&models.Auction{}).Scopes(scope).Where("ended = ?", false).Count(&activeAuctions)
+	db.Model(&models.Auction{}).Scopes(scope).Where("ended = ?", true).Count(&endedAuctions)
+	db.Model(&models.Bid{}).Scopes(scope).Count(&totalBids)
 
 	// 计算 TVL（所有活跃拍卖的最高出价总和）
 	var activeAuctionsList []models.Auction
-	db.Where("ended = ?", false).Find(&activeAuctionsList)
+	db.Scopes(scope).Where("ended = ?", false).Find(&activeAuctionsList)
 
 	tvl := big.NewInt(0)
 	for _, auction := range activeAuctionsList {
@@ -649,7 +1023,7 @@ func GetEnhancedStats(c *gin.Context) {
 
 	// 计算总交易量（所有出价的总和）
 	var allBids []models.Bid
-	db.Find(&allBids)
+	db.Scopes(scope).Find(&allBids)
 
 	totalVolume := big.NewInt(0)
 	for _, bid := range allBids {
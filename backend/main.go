@@ -27,21 +27,36 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
+	// 加载服务端签名账户
+	signer, err := blockchain.NewSignerFromConfig()
+	if err != nil {
+		log.Fatalf("Failed to load operator signer: %v", err)
+	}
+	if signer != nil {
+		blockchain.SetOperatorSigner(signer)
+		log.Printf("Operator signer loaded: %s", signer.Address().Hex())
+	}
+
 	// 创建上下文
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// 启动事件监听器
-	listener, err := blockchain.NewEventListener()
-	if err != nil {
-		log.Fatalf("Failed to create event listener: %v", err)
+	// 为每条链建立 RPC 节点池，监听器和合约服务共享，ETH_RPC_URL 所在的链为默认链
+	if err := blockchain.InitRPCPools(ctx); err != nil {
+		log.Fatalf("Failed to initialize RPC pools: %v", err)
 	}
 
-	go func() {
-		if err := listener.StartListening(ctx); err != nil {
-			log.Printf("Event listener error: %v", err)
-		}
-	}()
+	// 将 CONTRACT_ADDRESS 登记为默认合约
+	if err := blockchain.EnsureDefaultContract(ctx); err != nil {
+		log.Fatalf("Failed to register default contract: %v", err)
+	}
+
+	// 为注册表中启用的合约各启动一个监听器，连接合约所在链的节点，由守护负责断线重连和补齐缺口
+	// 每个合约同时定期与合约对账、自动结束到期拍卖，多实例部署时由租约保证只有一个实例提交交易
+	// 合约所在链尚无交易跟踪时一并启动，跟踪通过后端广播的交易状态
+	if err := blockchain.StartIndexing(ctx); err != nil {
+		log.Fatalf("Failed to start event listeners: %v", err)
+	}
 
 	// 设置 Gin 路由
 	gin.SetMode(gin.ReleaseMode)
@@ -6,44 +6,54 @@ import (
 
 // Auction 拍卖表
 type Auction struct {
-	ID            uint      `gorm:"primaryKey" json:"id"`
-	AuctionID     uint      `gorm:"uniqueIndex;not null" json:"auction_id"` // 链上拍卖ID
-	Seller        string    `gorm:"size:42;not null;index" json:"seller"`
-	NFTContract   string    `gorm:"size:42;not null;index" json:"nft_contract"`
-	TokenID       string    `gorm:"size:78;not null" json:"token_id"`
-	StartPrice    string    `gorm:"size:78;not null" json:"start_price"`
-	Duration      uint64    `gorm:"not null" json:"duration"`
-	StartTime     uint64    `gorm:"not null;index" json:"start_time"`
-	Ended         bool      `gorm:"default:false;index" json:"ended"`
-	HighestBidder string    `gorm:"size:42" json:"highest_bidder"`
-	HighestBid    string    `gorm:"size:78" json:"highest_bid"`
-	TokenAddress  string    `gorm:"size:42" json:"token_address"` // 出价代币地址，0x0为ETH
-	EndTime       *uint64   `json:"end_time"`                     // 实际结束时间
-	BidCount      int       `gorm:"default:0" json:"bid_count"`   // 出价次数
-	Category      string    `gorm:"size:50;index" json:"category"` // 分类
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	ChainID         uint64    `gorm:"uniqueIndex:idx_auction_chain_identity;not null" json:"chain_id"`                 // 拍卖合约所在链ID
+	ContractAddress string    `gorm:"size:42;uniqueIndex:idx_auction_chain_identity;not null" json:"contract_address"` // 拍卖合约地址
+	AuctionID       uint      `gorm:"uniqueIndex:idx_auction_chain_identity;not null" json:"auction_id"`               // 链上拍卖ID
+	Seller          string    `gorm:"size:42;not null;index" json:"seller"`
+	NFTContract     string    `gorm:"size:42;not null;index" json:"nft_contract"`
+	TokenID         string    `gorm:"size:78;not null" json:"token_id"`
+	StartPrice      string    `gorm:"size:78;not null" json:"start_price"`
+	Duration        uint64    `gorm:"not null" json:"duration"`
+	StartTime       uint64    `gorm:"not null;index" json:"start_time"`
+	Ended           bool      `gorm:"default:false;index" json:"ended"`
+	HighestBidder   string    `gorm:"size:42" json:"highest_bidder"`
+	HighestBid      string    `gorm:"size:78" json:"highest_bid"`
+	TokenAddress    string    `gorm:"size:42" json:"token_address"`         // 出价代币地址，0x0为ETH
+	EndTime         *uint64   `json:"end_time"`                             // 实际结束时间
+	BidCount        int       `gorm:"default:0" json:"bid_count"`           // 出价次数
+	Category        string    `gorm:"size:50;index" json:"category"`        // 分类
+	CreatedBlock    uint64    `gorm:"index" json:"created_block"`           // 创建事件所在区块
+	EndedBlock      *uint64   `gorm:"index" json:"ended_block"`             // 结束事件所在区块
+	Confirmed       bool      `gorm:"default:false;index" json:"confirmed"` // 相关事件是否均已达到确认深度
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
 	// 关联的 NFT 元数据（非数据库字段）
 	NFTMetadata *NFTMetadata `gorm:"-" json:"nft_metadata,omitempty"`
 }
 
 // Bid 出价记录表
 type Bid struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	AuctionID    uint      `gorm:"not null;index" json:"auction_id"` // 链上拍卖ID
-	Bidder       string    `gorm:"size:42;not null;index" json:"bidder"`
-	Amount       string    `gorm:"size:78;not null" json:"amount"`
-	TokenAddress string    `gorm:"size:42;not null" json:"token_address"`
-	TxHash       string    `gorm:"size:66;uniqueIndex" json:"tx_hash"`
-	BlockNumber  uint64    `gorm:"not null;index" json:"block_number"`
-	Timestamp    uint64    `gorm:"not null;index" json:"timestamp"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	ChainID         uint64    `gorm:"not null;index:idx_bid_chain_auction;uniqueIndex:idx_bid_chain_event" json:"chain_id"`                 // 拍卖合约所在链ID
+	ContractAddress string    `gorm:"size:42;not null;index:idx_bid_chain_auction;uniqueIndex:idx_bid_chain_event" json:"contract_address"` // 拍卖合约地址
+	AuctionID       uint      `gorm:"not null;index:idx_bid_chain_auction" json:"auction_id"`                                               // 链上拍卖ID
+	Bidder          string    `gorm:"size:42;not null;index" json:"bidder"`
+	Amount          string    `gorm:"size:78;not null" json:"amount"`
+	TokenAddress    string    `gorm:"size:42;not null" json:"token_address"`
+	TxHash          string    `gorm:"size:66;uniqueIndex:idx_bid_chain_event" json:"tx_hash"`
+	LogIndex        uint      `gorm:"uniqueIndex:idx_bid_chain_event;not null" json:"log_index"` // 调用解码模式下为交易在区块中的序号
+	BlockNumber     uint64    `gorm:"not null;index" json:"block_number"`
+	Timestamp       uint64    `gorm:"not null;index" json:"timestamp"`
+	Confirmed       bool      `gorm:"default:false;index" json:"confirmed"` // 是否已达到确认深度
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 // NFTMetadata NFT 元数据表
 type NFTMetadata struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
+	ChainID     uint64    `gorm:"not null;index" json:"chain_id"`
 	Contract    string    `gorm:"size:42;not null;index" json:"contract"`
 	TokenID     string    `gorm:"size:78;not null;index" json:"token_id"`
 	Name        string    `gorm:"size:255" json:"name"`
@@ -60,7 +70,8 @@ type NFTMetadata struct {
 // NFTCollection NFT 集合信息表
 type NFTCollection struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
-	Contract    string    `gorm:"size:42;uniqueIndex;not null" json:"contract"`
+	ChainID     uint64    `gorm:"uniqueIndex:idx_collection_identity;not null" json:"chain_id"`
+	Contract    string    `gorm:"size:42;uniqueIndex:idx_collection_identity;not null" json:"contract"`
 	Name        string    `gorm:"size:255" json:"name"`
 	Symbol      string    `gorm:"size:50" json:"symbol"`
 	TotalSupply int64     `json:"total_supply"`
@@ -73,6 +84,204 @@ type NFTCollection struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// SyncCursor 同步游标表（按链和合约地址记录事件处理进度）
+type SyncCursor struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	ChainID         uint64    `gorm:"uniqueIndex:idx_sync_cursor;not null" json:"chain_id"`
+	ContractAddress string    `gorm:"size:42;uniqueIndex:idx_sync_cursor;not null" json:"contract_address"`
+	BlockNumber     uint64    `gorm:"not null" json:"block_number"`         // 最后处理的区块号
+	LogIndex        int       `gorm:"not null;default:-1" json:"log_index"` // 最后处理的日志索引，-1 表示整个区块已处理完
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// IndexedBlock 已索引区块表（记录近期区块哈希，用于检测链重组）
+type IndexedBlock struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	ChainID         uint64    `gorm:"uniqueIndex:idx_indexed_block;not null" json:"chain_id"`
+	ContractAddress string    `gorm:"size:42;uniqueIndex:idx_indexed_block;not null" json:"contract_address"`
+	BlockNumber     uint64    `gorm:"uniqueIndex:idx_indexed_block;not null" json:"block_number"`
+	BlockHash       string    `gorm:"size:66;not null" json:"block_hash"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Event 事件账本表（以链、合约、交易哈希和日志索引唯一标识已应用的链上事件）
+type Event struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	ChainID         uint64    `gorm:"not null;index;uniqueIndex:idx_event_chain_identity" json:"chain_id"`
+	ContractAddress string    `gorm:"size:42;not null;index;uniqueIndex:idx_event_chain_identity" json:"contract_address"`
+	TxHash          string    `gorm:"size:66;uniqueIndex:idx_event_chain_identity;not null" json:"tx_hash"`
+	LogIndex        uint      `gorm:"uniqueIndex:idx_event_chain_identity;not null" json:"log_index"` // 调用解码模式下为交易在区块中的序号
+	BlockNumber     uint64    `gorm:"not null;index" json:"block_number"`
+	BlockHash       string    `gorm:"size:66;not null" json:"block_hash"`
+	EventName       string    `gorm:"size:64;not null" json:"event_name"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// 死信状态
+const (
+	DeadLetterPending   = "pending"   // 待处理
+	DeadLetterResolved  = "resolved"  // 重试成功
+	DeadLetterDiscarded = "discarded" // 已丢弃
+)
+
+// 死信来源
+const (
+	DeadLetterSourceLog  = "log"  // 事件日志
+	DeadLetterSourceCall = "call" // 调用解码模式下的交易调用
+)
+
+// DeadLetter 死信表（解码或写库失败的日志和交易调用）
+type DeadLetter struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	ChainID         uint64     `gorm:"not null;index;uniqueIndex:idx_dead_letter_chain_identity" json:"chain_id"`
+	ContractAddress string     `gorm:"size:42;not null;index;uniqueIndex:idx_dead_letter_chain_identity" json:"contract_address"`
+	TxHash          string     `gorm:"size:66;uniqueIndex:idx_dead_letter_chain_identity;not null" json:"tx_hash"`
+	LogIndex        uint       `gorm:"uniqueIndex:idx_dead_letter_chain_identity;not null" json:"log_index"` // 交易调用为交易在区块中的序号
+	BlockNumber     uint64     `gorm:"not null;index" json:"block_number"`
+	Source          string     `gorm:"size:10;not null;default:log" json:"source"` // log, call
+	EventName       string     `gorm:"size:64" json:"event_name"`                  // 交易调用为方法名
+	RawLog          string     `gorm:"type:text;not null" json:"raw_log"`          // 原始日志 JSON，交易调用为原始交易 JSON
+	Error           string     `gorm:"type:text" json:"error"`
+	Attempts        int        `gorm:"default:1" json:"attempts"`
+	Status          string     `gorm:"size:20;default:pending;index" json:"status"`
+	ResolvedAt      *time.Time `json:"resolved_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ReconcileReport 对账报告表（每次链上对账运行一条）
+type ReconcileReport struct {
+	ID              uint                 `gorm:"primaryKey" json:"id"`
+	ChainID         uint64               `gorm:"index" json:"chain_id"`                 // 对账的拍卖合约所在链ID
+	ContractAddress string               `gorm:"size:42;index" json:"contract_address"` // 对账的拍卖合约地址
+	TriggeredBy     string               `gorm:"size:20;not null" json:"triggered_by"`  // schedule, manual
+	BlockNumber     uint64               `gorm:"not null" json:"block_number"`          // 对账时的最新区块
+	Checked         int                  `gorm:"default:0" json:"checked"`              // 检查的拍卖数
+	Mismatched      int                  `gorm:"default:0" json:"mismatched"`           // 存在差异的拍卖数
+	Repaired        int                  `gorm:"default:0" json:"repaired"`             // 已修复的拍卖数
+	Error           string               `gorm:"type:text" json:"error,omitempty"`      // 运行失败原因
+	StartedAt       time.Time            `gorm:"not null;index" json:"started_at"`
+	FinishedAt      *time.Time           `json:"finished_at"`
+	Discrepancies   []AuctionDiscrepancy `gorm:"foreignKey:ReportID" json:"discrepancies,omitempty"`
+}
+
+// AuctionDiscrepancy 拍卖差异记录表（本地数据与合约 auctions(i) 不一致的字段）
+type AuctionDiscrepancy struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ReportID   uint      `gorm:"not null;index" json:"report_id"`
+	AuctionID  uint      `gorm:"not null;index" json:"auction_id"`
+	Field      string    `gorm:"size:32;not null" json:"field"`
+	LocalValue string    `gorm:"size:78" json:"local_value"`
+	ChainValue string    `gorm:"size:78" json:"chain_value"`
+	Repaired   bool      `gorm:"default:false" json:"repaired"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// 交易状态
+const (
+	TxStatusPending  = "pending"  // 已广播，等待打包
+	TxStatusMined    = "mined"    // 已打包且执行成功
+	TxStatusFailed   = "failed"   // 已打包但执行失败
+	TxStatusReplaced = "replaced" // 同一 nonce 被其他交易占用（加速或取消）
+	TxStatusDropped  = "dropped"  // 从交易池中消失且未被打包
+)
+
+// Transaction 交易表（通过后端广播的用户交易及其生命周期）
+type Transaction struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	TxHash            string     `gorm:"size:66;uniqueIndex;not null" json:"tx_hash"`
+	ChainID           uint64     `gorm:"not null" json:"chain_id"`
+	FromAddress       string     `gorm:"size:42;not null;index:idx_tx_sender" json:"from_address"`
+	ToAddress         string     `gorm:"size:42;not null" json:"to_address"`
+	Nonce             uint64     `gorm:"not null;index:idx_tx_sender" json:"nonce"`
+	Method            string     `gorm:"size:64" json:"method"`
+	AuctionID         *uint      `gorm:"index" json:"auction_id"`
+	Value             string     `gorm:"size:78" json:"value"`
+	Status            string     `gorm:"size:20;default:pending;index" json:"status"`
+	BlockNumber       *uint64    `json:"block_number"`
+	BlockHash         string     `gorm:"size:66" json:"block_hash,omitempty"`
+	GasUsed           *uint64    `json:"gas_used"`
+	EffectiveGasPrice string     `gorm:"size:78" json:"effective_gas_price,omitempty"`
+	ReplacedBy        string     `gorm:"size:66" json:"replaced_by,omitempty"` // 替代交易的哈希
+	SubmittedAt       time.Time  `gorm:"not null" json:"submitted_at"`
+	MinedAt           *time.Time `json:"mined_at"`
+	LastCheckedAt     *time.Time `json:"last_checked_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// PriceFeed 价格预言机表（合约的 priceFeeds 映射不可遍历，记录通过后端设置过的代币）
+type PriceFeed struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ChainID      uint64    `gorm:"uniqueIndex:idx_price_feed_chain_token;not null" json:"chain_id"`
+	TokenAddress string    `gorm:"size:42;uniqueIndex:idx_price_feed_chain_token;not null" json:"token_address"` // 代币地址，零地址表示ETH
+	FeedAddress  string    `gorm:"size:42;not null" json:"feed_address"`                                         // 预言机地址
+	TxHash       string    `gorm:"size:66" json:"tx_hash"`                                                       // 最近一次 setPriceFeed 交易
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// 自动结束拍卖任务状态
+const (
+	KeeperJobPending   = "pending"   // 等待提交或重试
+	KeeperJobSubmitted = "submitted" // 交易已广播，等待打包
+	KeeperJobSucceeded = "succeeded" // 交易已成功打包
+	KeeperJobFailed    = "failed"    // 达到最大次数仍未成功
+	KeeperJobReverted  = "reverted"  // 模拟执行被合约拒绝，不再自动重试
+	KeeperJobSkipped   = "skipped"   // 拍卖已被其他交易结束
+)
+
+// KeeperJob 自动结束拍卖任务表，每个拍卖一条，记录提交过程和结果
+type KeeperJob struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	ChainID         uint64     `gorm:"uniqueIndex:idx_keeper_job_identity;not null" json:"chain_id"`
+	ContractAddress string     `gorm:"size:42;uniqueIndex:idx_keeper_job_identity;not null" json:"contract_address"`
+	AuctionID       uint       `gorm:"uniqueIndex:idx_keeper_job_identity;not null" json:"auction_id"`
+	Status          string     `gorm:"size:20;default:pending;index" json:"status"`
+	Attempts        int        `gorm:"default:0" json:"attempts"`
+	TxHash          string     `gorm:"size:66" json:"tx_hash,omitempty"` // 最近一次提交的交易
+	LastError       string     `gorm:"type:text" json:"last_error,omitempty"`
+	NextAttemptAt   time.Time  `gorm:"index" json:"next_attempt_at"`
+	SubmittedAt     *time.Time `json:"submitted_at"`
+	FinishedAt      *time.Time `json:"finished_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Lease 分布式租约表，多实例部署时保证同一任务只有一个实例执行
+type Lease struct {
+	Name      string    `gorm:"primaryKey;size:64" json:"name"`
+	Holder    string    `gorm:"size:128;not null" json:"holder"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Implementation 代理合约实现历史表，每次升级开始一个新的区块区间
+type Implementation struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	ChainID        uint64    `gorm:"not null;uniqueIndex:idx_impl_period" json:"chain_id"`
+	ProxyAddress   string    `gorm:"size:42;not null;uniqueIndex:idx_impl_period" json:"proxy_address"`
+	Implementation string    `gorm:"size:42;not null" json:"implementation"`
+	ABIVersion     string    `gorm:"size:16;not null" json:"abi_version"`                    // 解码该区间日志和调用使用的 ABI 版本
+	FromBlock      uint64    `gorm:"not null;uniqueIndex:idx_impl_period" json:"from_block"` // 生效区块
+	ToBlock        *uint64   `json:"to_block"`                                               // 最后生效区块，nil 表示当前实现
+	TxHash         string    `gorm:"size:66" json:"tx_hash,omitempty"`                       // 升级交易，通过读取存储槽发现时为空
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Contract 拍卖合约注册表，每个启用的部署由独立的监听器索引
+type Contract struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ChainID    uint64    `gorm:"not null;uniqueIndex:idx_contract_identity" json:"chain_id"`
+	Address    string    `gorm:"size:42;not null;uniqueIndex:idx_contract_identity" json:"address"`
+	Name       string    `gorm:"size:100" json:"name"`                  // 部署名称，如 test、staging
+	StartBlock uint64    `gorm:"not null;default:0" json:"start_block"` // 首次同步的起始区块
+	ABIVersion string    `gorm:"size:16" json:"abi_version"`            // 固定使用的 ABI 版本，为空时按实现历史选择
+	Enabled    bool      `gorm:"not null;default:true;index" json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
 // TableName 指定表名
 func (Auction) TableName() string {
 	return "auctions"
@@ -89,3 +298,51 @@ func (NFTMetadata) TableName() string {
 func (NFTCollection) TableName() string {
 	return "nft_collections"
 }
+
+func (SyncCursor) TableName() string {
+	return "sync_cursors"
+}
+
+func (IndexedBlock) TableName() string {
+	return "indexed_blocks"
+}
+
+func (Event) TableName() string {
+	return "events"
+}
+
+func (DeadLetter) TableName() string {
+	return "dead_letters"
+}
+
+func (ReconcileReport) TableName() string {
+	return "reconcile_reports"
+}
+
+func (AuctionDiscrepancy) TableName() string {
+	return "auction_discrepancies"
+}
+
+func (Transaction) TableName() string {
+	return "transactions"
+}
+
+func (PriceFeed) TableName() string {
+	return "price_feeds"
+}
+
+func (KeeperJob) TableName() string {
+	return "keeper_jobs"
+}
+
+func (Lease) TableName() string {
+	return "leases"
+}
+
+func (Implementation) TableName() string {
+	return "contract_implementations"
+}
+
+func (Contract) TableName() string {
+	return "contracts"
+}
@@ -15,12 +15,16 @@ func SetupRoutes(r *gin.Engine) {
 	api := r.Group("/api")
 	{
 		// 拍卖相关
-		api.GET("/auctions", handlers.GetAuctionList)              // 获取拍卖列表（支持排序和分类）
+		api.GET("/auctions", handlers.GetAuctionList)              // 获取拍卖列表（支持排序、分类和确认状态过滤）
 		api.GET("/auctions/:id", handlers.GetAuctionDetail)        // 获取拍卖详情
 		api.GET("/auctions/:id/bids", handlers.GetAuctionBids)     // 获取拍卖的出价历史
 		api.GET("/auctions/:id/contract", handlers.GetContractAuctionInfo) // 从合约读取拍卖信息
-		api.POST("/auctions/:id/bid", handlers.PlaceBid)           // 参与出价
-		api.POST("/auctions/:id/end", handlers.EndAuction)         // 结束拍卖
+		api.POST("/auctions/:id/bid", handlers.PlaceBid)           // 构造出价交易（由钱包签名）
+		api.POST("/auctions/:id/end", handlers.EndAuction)         // 构造结束拍卖交易（由钱包签名）
+
+		// 交易相关
+		api.POST("/tx/broadcast", handlers.BroadcastTx)            // 广播钱包签名的交易
+		api.GET("/tx/:hash", handlers.GetTransactionStatus)        // 获取交易状态
 
 		// 出价相关
 		api.GET("/bids", handlers.GetBidsByBidder)                 // 获取某个地址的出价记录
@@ -31,7 +35,42 @@ func SetupRoutes(r *gin.Engine) {
 		api.GET("/nft/:contract/:token_id/metadata", handlers.GetNFTMetadata) // 获取 NFT 元数据
 
 		// 统计信息
-		api.GET("/stats", handlers.GetStats)                       // 获取基本统计信息
+		api.GET("/stats", handlers.GetStats)                       // 获取基本统计信息（支持确认状态过滤）
 		api.GET("/stats/enhanced", handlers.GetEnhancedStats)      // 获取增强统计信息（含 TVL）
 	}
+
+	// 管理接口
+	admin := r.Group("/api/admin", handlers.AdminAuth())
+	{
+		// 死信队列
+		admin.GET("/dead-letters", handlers.ListDeadLetters)               // 获取死信列表
+		admin.GET("/dead-letters/:id", handlers.GetDeadLetter)             // 获取死信详情
+		admin.POST("/dead-letters/:id/retry", handlers.RetryDeadLetter)    // 重新处理死信
+		admin.POST("/dead-letters/:id/discard", handlers.DiscardDeadLetter) // 丢弃死信
+
+		// 链上对账
+		admin.POST("/reconcile", handlers.RunReconcile)                      // 立即执行对账
+		admin.GET("/reconcile/reports", handlers.ListReconcileReports)       // 获取对账报告列表
+		admin.GET("/reconcile/reports/:id", handlers.GetReconcileReport)     // 获取对账报告详情
+
+		// 拍卖管理
+		admin.POST("/auctions", handlers.CreateAuction) // 创建拍卖（服务端签名账户调用 createAuction）
+
+		// 价格预言机
+		admin.GET("/price-feeds", handlers.ListPriceFeeds)        // 获取已知代币的预言机状态
+		admin.GET("/price-feeds/:token", handlers.GetPriceFeed)   // 获取代币的预言机状态
+		admin.POST("/price-feeds", handlers.SetPriceFeed)         // 设置代币的预言机
+
+		// 自动结束拍卖
+		admin.GET("/keeper/jobs", handlers.ListKeeperJobs)             // 获取自动结束拍卖任务列表
+		admin.POST("/keeper/jobs/:id/retry", handlers.RetryKeeperJob)  // 重新提交任务
+
+		// 合约升级
+		admin.GET("/implementations", handlers.ListImplementations) // 获取代理合约实现历史
+
+		// 合约注册表
+		admin.GET("/contracts", handlers.ListContracts)        // 获取拍卖合约注册表
+		admin.POST("/contracts", handlers.RegisterContract)    // 注册拍卖合约并开始索引
+		admin.PUT("/contracts/:id", handlers.UpdateContract)   // 修改、启用或停用拍卖合约
+	}
 }
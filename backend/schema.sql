@@ -6,7 +6,9 @@ USE nft_auction;
 -- 拍卖表
 CREATE TABLE IF NOT EXISTS auctions (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
-    auction_id BIGINT UNSIGNED NOT NULL UNIQUE COMMENT '链上拍卖ID',
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '拍卖合约所在链ID',
+    contract_address VARCHAR(42) NOT NULL COMMENT '拍卖合约地址',
+    auction_id BIGINT UNSIGNED NOT NULL COMMENT '链上拍卖ID',
     seller VARCHAR(42) NOT NULL COMMENT '卖家地址',
     nft_contract VARCHAR(42) NOT NULL COMMENT 'NFT合约地址',
     token_id VARCHAR(78) NOT NULL COMMENT 'NFT TokenID',
@@ -18,27 +20,230 @@ CREATE TABLE IF NOT EXISTS auctions (
     highest_bid VARCHAR(78) COMMENT '最高出价',
     token_address VARCHAR(42) COMMENT '出价代币地址',
     end_time BIGINT UNSIGNED COMMENT '实际结束时间',
+    created_block BIGINT UNSIGNED COMMENT '创建事件所在区块',
+    ended_block BIGINT UNSIGNED COMMENT '结束事件所在区块',
+    confirmed BOOLEAN DEFAULT FALSE COMMENT '相关事件是否均已确认',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_auction_chain_identity (chain_id, contract_address, auction_id),
     INDEX idx_seller (seller),
     INDEX idx_nft_contract (nft_contract),
     INDEX idx_start_time (start_time),
-    INDEX idx_ended (ended)
+    INDEX idx_ended (ended),
+    INDEX idx_created_block (created_block),
+    INDEX idx_ended_block (ended_block),
+    INDEX idx_confirmed (confirmed)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='拍卖表';
 
 -- 出价记录表
 CREATE TABLE IF NOT EXISTS bids (
     id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '拍卖合约所在链ID',
+    contract_address VARCHAR(42) NOT NULL COMMENT '拍卖合约地址',
     auction_id BIGINT UNSIGNED NOT NULL COMMENT '链上拍卖ID',
     bidder VARCHAR(42) NOT NULL COMMENT '出价者地址',
     amount VARCHAR(78) NOT NULL COMMENT '出价金额',
     token_address VARCHAR(42) NOT NULL COMMENT '出价代币地址',
-    tx_hash VARCHAR(66) NOT NULL UNIQUE COMMENT '交易哈希',
+    tx_hash VARCHAR(66) NOT NULL COMMENT '交易哈希',
+    log_index INT UNSIGNED NOT NULL COMMENT '日志索引',
     block_number BIGINT UNSIGNED NOT NULL COMMENT '区块号',
     timestamp BIGINT UNSIGNED NOT NULL COMMENT '时间戳',
+    confirmed BOOLEAN DEFAULT FALSE COMMENT '是否已确认',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    INDEX idx_auction_id (auction_id),
+    INDEX idx_bid_chain_auction (chain_id, contract_address, auction_id),
     INDEX idx_bidder (bidder),
     INDEX idx_block_number (block_number),
-    INDEX idx_timestamp (timestamp)
+    INDEX idx_timestamp (timestamp),
+    INDEX idx_bid_confirmed (confirmed),
+    UNIQUE INDEX idx_bid_chain_event (chain_id, contract_address, tx_hash, log_index)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='出价记录表';
+
+-- 同步游标表
+CREATE TABLE IF NOT EXISTS sync_cursors (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+    block_number BIGINT UNSIGNED NOT NULL COMMENT '最后处理的区块号',
+    log_index INT NOT NULL DEFAULT -1 COMMENT '最后处理的日志索引，-1表示整个区块已处理完',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_sync_cursor (chain_id, contract_address)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='同步游标表';
+
+-- 已索引区块表
+CREATE TABLE IF NOT EXISTS indexed_blocks (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+    block_number BIGINT UNSIGNED NOT NULL COMMENT '区块号',
+    block_hash VARCHAR(66) NOT NULL COMMENT '区块哈希',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_indexed_block (chain_id, contract_address, block_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='已索引区块表';
+
+-- 事件账本表
+CREATE TABLE IF NOT EXISTS events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+    tx_hash VARCHAR(66) NOT NULL COMMENT '交易哈希',
+    log_index INT UNSIGNED NOT NULL COMMENT '日志索引',
+    block_number BIGINT UNSIGNED NOT NULL COMMENT '区块号',
+    block_hash VARCHAR(66) NOT NULL COMMENT '区块哈希',
+    event_name VARCHAR(64) NOT NULL COMMENT '事件名称',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_event_chain_identity (chain_id, contract_address, tx_hash, log_index),
+    INDEX idx_event_chain (chain_id),
+    INDEX idx_event_contract (contract_address),
+    INDEX idx_event_block (block_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='事件账本表';
+
+-- 死信表
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    contract_address VARCHAR(42) NOT NULL COMMENT '合约地址',
+    tx_hash VARCHAR(66) NOT NULL COMMENT '交易哈希',
+    log_index INT UNSIGNED NOT NULL COMMENT '日志索引',
+    block_number BIGINT UNSIGNED NOT NULL COMMENT '区块号',
+    source VARCHAR(10) NOT NULL DEFAULT 'log' COMMENT '来源: log, call',
+    event_name VARCHAR(64) COMMENT '事件名称，交易调用为方法名',
+    raw_log TEXT NOT NULL COMMENT '原始日志JSON，交易调用为原始交易JSON',
+    error TEXT COMMENT '失败原因',
+    attempts INT DEFAULT 1 COMMENT '处理次数',
+    status VARCHAR(20) DEFAULT 'pending' COMMENT '状态: pending, resolved, discarded',
+    resolved_at TIMESTAMP NULL COMMENT '处理完成时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_dead_letter_chain_identity (chain_id, contract_address, tx_hash, log_index),
+    INDEX idx_dead_letter_status (status),
+    INDEX idx_dead_letter_block (block_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='死信表';
+
+-- 对账报告表
+CREATE TABLE IF NOT EXISTS reconcile_reports (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED COMMENT '对账的拍卖合约所在链ID',
+    contract_address VARCHAR(42) COMMENT '对账的拍卖合约地址',
+    triggered_by VARCHAR(20) NOT NULL COMMENT '触发方式: schedule, manual',
+    block_number BIGINT UNSIGNED NOT NULL COMMENT '对账时的最新区块',
+    checked INT DEFAULT 0 COMMENT '检查的拍卖数',
+    mismatched INT DEFAULT 0 COMMENT '存在差异的拍卖数',
+    repaired INT DEFAULT 0 COMMENT '已修复的拍卖数',
+    error TEXT COMMENT '运行失败原因',
+    started_at TIMESTAMP NOT NULL COMMENT '开始时间',
+    finished_at TIMESTAMP NULL COMMENT '结束时间',
+    INDEX idx_reconcile_chain (chain_id),
+    INDEX idx_reconcile_contract (contract_address),
+    INDEX idx_reconcile_started (started_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='对账报告表';
+
+-- 拍卖差异记录表
+CREATE TABLE IF NOT EXISTS auction_discrepancies (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    report_id BIGINT UNSIGNED NOT NULL COMMENT '对账报告ID',
+    auction_id BIGINT UNSIGNED NOT NULL COMMENT '链上拍卖ID',
+    field VARCHAR(32) NOT NULL COMMENT '差异字段',
+    local_value VARCHAR(78) COMMENT '本地值',
+    chain_value VARCHAR(78) COMMENT '合约值',
+    repaired BOOLEAN DEFAULT FALSE COMMENT '是否已修复',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_discrepancy_report (report_id),
+    INDEX idx_discrepancy_auction (auction_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='拍卖差异记录表';
+
+-- 交易表
+CREATE TABLE IF NOT EXISTS transactions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    tx_hash VARCHAR(66) NOT NULL COMMENT '交易哈希',
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    from_address VARCHAR(42) NOT NULL COMMENT '发送方地址',
+    to_address VARCHAR(42) NOT NULL COMMENT '接收方地址',
+    nonce BIGINT UNSIGNED NOT NULL COMMENT '交易nonce',
+    method VARCHAR(64) COMMENT '调用的合约方法',
+    auction_id BIGINT UNSIGNED COMMENT '链上拍卖ID',
+    value VARCHAR(78) COMMENT '附带的ETH（wei）',
+    status VARCHAR(20) DEFAULT 'pending' COMMENT '状态: pending, mined, failed, replaced, dropped',
+    block_number BIGINT UNSIGNED NULL COMMENT '打包区块号',
+    block_hash VARCHAR(66) COMMENT '打包区块哈希',
+    gas_used BIGINT UNSIGNED NULL COMMENT '实际消耗的gas',
+    effective_gas_price VARCHAR(78) COMMENT '实际gas价格（wei）',
+    replaced_by VARCHAR(66) COMMENT '替代交易哈希',
+    submitted_at TIMESTAMP NOT NULL COMMENT '广播时间',
+    mined_at TIMESTAMP NULL COMMENT '打包时间',
+    last_checked_at TIMESTAMP NULL COMMENT '最后检查时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_tx_hash (tx_hash),
+    INDEX idx_tx_sender (from_address, nonce),
+    INDEX idx_tx_status (status),
+    INDEX idx_tx_auction (auction_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='交易表';
+
+-- 价格预言机表
+CREATE TABLE IF NOT EXISTS price_feeds (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    token_address VARCHAR(42) NOT NULL COMMENT '代币地址，零地址表示ETH',
+    feed_address VARCHAR(42) NOT NULL COMMENT '预言机地址',
+    tx_hash VARCHAR(66) COMMENT '最近一次setPriceFeed交易哈希',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_price_feed_chain_token (chain_id, token_address)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='价格预言机表';
+
+-- 自动结束拍卖任务表
+CREATE TABLE IF NOT EXISTS keeper_jobs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '拍卖合约所在链ID',
+    contract_address VARCHAR(42) NOT NULL COMMENT '拍卖合约地址',
+    auction_id BIGINT UNSIGNED NOT NULL COMMENT '链上拍卖ID',
+    status VARCHAR(20) DEFAULT 'pending' COMMENT '状态: pending, submitted, succeeded, failed, reverted, skipped',
+    attempts INT DEFAULT 0 COMMENT '已提交次数',
+    tx_hash VARCHAR(66) COMMENT '最近一次提交的交易哈希',
+    last_error TEXT COMMENT '最近一次错误',
+    next_attempt_at TIMESTAMP NOT NULL COMMENT '下次处理时间',
+    submitted_at TIMESTAMP NULL COMMENT '最近一次提交时间',
+    finished_at TIMESTAMP NULL COMMENT '完成时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_keeper_job_identity (chain_id, contract_address, auction_id),
+    INDEX idx_keeper_job_status (status),
+    INDEX idx_keeper_job_next (next_attempt_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='自动结束拍卖任务表';
+
+-- 分布式租约表
+CREATE TABLE IF NOT EXISTS leases (
+    name VARCHAR(64) PRIMARY KEY COMMENT '租约名称',
+    holder VARCHAR(128) NOT NULL COMMENT '持有者实例标识',
+    expires_at TIMESTAMP NOT NULL COMMENT '过期时间',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='分布式租约表';
+
+-- 代理合约实现历史表
+CREATE TABLE IF NOT EXISTS contract_implementations (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    proxy_address VARCHAR(42) NOT NULL COMMENT '代理合约地址',
+    implementation VARCHAR(42) NOT NULL COMMENT '实现合约地址',
+    abi_version VARCHAR(16) NOT NULL COMMENT '解码使用的ABI版本',
+    from_block BIGINT UNSIGNED NOT NULL COMMENT '生效区块',
+    to_block BIGINT UNSIGNED NULL COMMENT '最后生效区块，NULL表示当前实现',
+    tx_hash VARCHAR(66) COMMENT '升级交易哈希',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_impl_period (chain_id, proxy_address, from_block)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='代理合约实现历史表';
+
+-- 拍卖合约注册表
+CREATE TABLE IF NOT EXISTS contracts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    chain_id BIGINT UNSIGNED NOT NULL COMMENT '链ID',
+    address VARCHAR(42) NOT NULL COMMENT '拍卖合约地址',
+    name VARCHAR(100) COMMENT '部署名称',
+    start_block BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '首次同步的起始区块',
+    abi_version VARCHAR(16) COMMENT '固定使用的ABI版本，为空时按实现历史选择',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE COMMENT '是否索引',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_contract_identity (chain_id, address),
+    INDEX idx_contract_enabled (enabled)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='拍卖合约注册表';
@@ -4,6 +4,7 @@ import (
 	"auction-backend/config"
 	"auction-backend/models"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,7 +19,10 @@ type AlchemyService struct {
 	client  *http.Client
 }
 
-// NewAlchemyService 创建 Alchemy 服务实例
+// ErrUnsupportedChain 未为该链配置 NFT 数据源
+var ErrUnsupportedChain = errors.New("chain is not supported by NFT data source")
+
+// NewAlchemyService 创建默认链的 Alchemy 服务实例
 func NewAlchemyService() *AlchemyService {
 	return &AlchemyService{
 		apiKey:  config.AppConfig.AlchemyAPIKey,
@@ -29,6 +33,18 @@ func NewAlchemyService() *AlchemyService {
 	}
 }
 
+// NewAlchemyServiceForChain 创建其他链的 Alchemy 服务实例，地址来自 ALCHEMY_CHAIN_URLS
+func NewAlchemyServiceForChain(chainID uint64) (*AlchemyService, error) {
+	baseURL, ok := config.AppConfig.AlchemyChainURLs[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChain, chainID)
+	}
+
+	s := NewAlchemyService()
+	s.baseURL = baseURL
+	return s, nil
+}
+
 // AlchemyNFT Alchemy NFT 响应
 type AlchemyNFT struct {
 	Contract struct {
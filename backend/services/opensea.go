@@ -13,20 +13,44 @@ import (
 type OpenSeaService struct {
 	apiKey  string
 	baseURL string
+	chain   string // OpenSea 的链标识
 	client  *http.Client
 }
 
-// NewOpenSeaService 创建 OpenSea 服务实例
+// openSeaChains 链ID对应的 OpenSea 链标识
+var openSeaChains = map[uint64]string{
+	1:        "ethereum",
+	10:       "optimism",
+	137:      "matic",
+	8453:     "base",
+	42161:    "arbitrum",
+	11155111: "sepolia",
+}
+
+// NewOpenSeaService 创建 OpenSea 服务实例，查询以太坊主网
 func NewOpenSeaService(apiKey string) *OpenSeaService {
 	return &OpenSeaService{
 		apiKey:  apiKey,
 		baseURL: "https://api.opensea.io/api/v2",
+		chain:   "ethereum",
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
 }
 
+// NewOpenSeaServiceForChain 创建查询指定链的 OpenSea 服务实例
+func NewOpenSeaServiceForChain(apiKey string, chainID uint64) (*OpenSeaService, error) {
+	chain, ok := openSeaChains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedChain, chainID)
+	}
+
+	s := NewOpenSeaService(apiKey)
+	s.chain = chain
+	return s, nil
+}
+
 // CollectionStats 集合统计信息
 type CollectionStats struct {
 	FloorPrice float64 `json:"floor_price"`
@@ -120,7 +144,7 @@ func (s *OpenSeaService) GetCollection(collectionSlug string) (*OpenSeaCollectio
 // GetCollectionByContract 通过合约地址获取集合 slug
 func (s *OpenSeaService) GetCollectionByContract(contractAddress string) (string, error) {
 	// OpenSea v2 API 需要通过合约地址查询集合
-	url := fmt.Sprintf("%s/chain/ethereum/contract/%s", s.baseURL, strings.ToLower(contractAddress))
+	url := fmt.Sprintf("%s/chain/%s/contract/%s", s.baseURL, s.chain, strings.ToLower(contractAddress))
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {